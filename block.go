@@ -0,0 +1,251 @@
+package packer
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/pierrec/packer/internal/packuint"
+)
+
+// BlockLen is the maximum number of values in a block.
+const BlockLen = 128
+
+// Blocks encode values using frame of reference with patching (PFOR): values are stored
+// as their difference to the smallest one of the block, on as many bits as the bulk
+// of them requires. The differences that do not fit are exceptions whose upper bits
+// are stored separately, after their index in the block.
+//
+// A block is laid out as follow:
+//  count-1     1 byte
+//  width       1 byte, bits per value
+//  exc width   1 byte, bits per exception
+//  exc count   1 byte
+//  min         packed uint64
+//  values      count*width bits
+//  exc indexes 1 byte per exception, increasing
+//  exc values  exc count*exc width bits
+// Bits are stored least significant first.
+
+// BlockStats describes an encoded block.
+type BlockStats struct {
+	Len            int    // number of values
+	Min            uint64 // smallest value
+	Width          int    // bits per value
+	Exceptions     int    // number of values not fitting Width bits
+	ExceptionWidth int    // additional bits per exception
+	Size           int    // encoded size in bytes
+}
+
+// blockSize returns the size of the bit packed data of a block.
+func blockSize(n, width, ne, ewidth int) int {
+	return (n*width+7)/8 + ne + (ne*ewidth+7)/8
+}
+
+// EncodeBlock appends the block made of the values of src, which must not hold more than BlockLen of them,
+// to dst and returns the extended buffer.
+func EncodeBlock(dst []byte, src []uint64) []byte {
+	if len(src) == 0 || len(src) > BlockLen {
+		panic(fmt.Sprintf("packer: invalid block length %d", len(src)))
+	}
+	min := src[0]
+	for _, x := range src[1:] {
+		if x < min {
+			min = x
+		}
+	}
+	// Pick the width resulting in the smallest block.
+	var hist [65]int
+	for _, x := range src {
+		hist[bits.Len64(x-min)]++
+	}
+	max := 64
+	for hist[max] == 0 {
+		max--
+	}
+	width, ne := max, 0
+	size := blockSize(len(src), max, 0, 0)
+	for w, e := max-1, hist[max]; w >= 0; w, e = w-1, e+hist[w] {
+		if s := blockSize(len(src), w, e, max-w); s < size {
+			width, ne, size = w, e, s
+		}
+	}
+	ewidth := 0
+	if ne > 0 {
+		ewidth = max - width
+	}
+
+	dst = append(dst, byte(len(src)-1), byte(width), byte(ewidth), byte(ne))
+	dst = packuint.AppendUint64(dst, min)
+	n := len(dst)
+	dst = append(dst, make([]byte, size)...)
+	values := dst[n : n+(len(src)*width+7)/8]
+	indexes := dst[n+len(values) : n+len(values)+ne]
+	exceptions := dst[n+len(values)+ne:]
+	var k int
+	for i, x := range src {
+		r := x - min
+		putBits(values, i*width, width, r)
+		if bits.Len64(r) > width {
+			indexes[k] = byte(i)
+			putBits(exceptions, k*ewidth, ewidth, r>>width)
+			k++
+		}
+	}
+	return dst
+}
+
+// EncodeBlocks appends the values of src to dst as consecutive blocks of BlockLen values,
+// the last one holding the remaining ones, and returns the extended buffer.
+func EncodeBlocks(dst []byte, src []uint64) []byte {
+	for len(src) > 0 {
+		n := len(src)
+		if n > BlockLen {
+			n = BlockLen
+		}
+		dst = EncodeBlock(dst, src[:n])
+		src = src[n:]
+	}
+	return dst
+}
+
+// DecodeBlocks appends the values of the blocks encoded in src to dst and returns the extended slice.
+// The values of the blocks decoded before an error are still appended to dst.
+func DecodeBlocks(dst []uint64, src []byte) ([]uint64, error) {
+	for len(src) > 0 {
+		b, err := ParseBlock(src)
+		if err != nil {
+			return dst, err
+		}
+		dst = b.AppendValues(dst)
+		src = src[b.stats.Size:]
+	}
+	return dst, nil
+}
+
+// Block gives access to the values of an encoded block.
+type Block struct {
+	stats      BlockStats
+	values     []byte
+	indexes    []byte
+	exceptions []byte
+}
+
+// ParseBlock returns the block encoded at the start of src.
+// Its size is given by its statistics.
+//
+// It returns an error wrapping ErrShortBuffer if src is too short for the block
+// or ErrCorrupt if the block header is not consistent.
+func ParseBlock(src []byte) (Block, error) {
+	const header = 4
+	if len(src) < header {
+		return Block{}, fmt.Errorf("packer: block: %w: %d bytes for header", ErrShortBuffer, len(src))
+	}
+	n, width, ewidth, ne := int(src[0])+1, int(src[1]), int(src[2]), int(src[3])
+	switch {
+	case n > BlockLen:
+		return Block{}, fmt.Errorf("packer: block: %w: %d values", ErrCorrupt, n)
+	case width+ewidth > 64:
+		return Block{}, fmt.Errorf("packer: block: %w: %d+%d bits per value", ErrCorrupt, width, ewidth)
+	case ne > n:
+		return Block{}, fmt.Errorf("packer: block: %w: %d exceptions for %d values", ErrCorrupt, ne, n)
+	}
+	min, m, err := DecodeUint64(src[header:])
+	if err != nil {
+		return Block{}, fmt.Errorf("packer: block: %w", err)
+	}
+	m += header
+	size := blockSize(n, width, ne, ewidth)
+	if len(src) < m+size {
+		return Block{}, fmt.Errorf("packer: block: %w: %d bytes for %d", ErrShortBuffer, len(src), m+size)
+	}
+	b := Block{
+		stats: BlockStats{
+			Len:            n,
+			Min:            min,
+			Width:          width,
+			Exceptions:     ne,
+			ExceptionWidth: ewidth,
+			Size:           m + size,
+		},
+	}
+	src = src[m : m+size]
+	b.values, src = src[:(n*width+7)/8], src[(n*width+7)/8:]
+	b.indexes, b.exceptions = src[:ne], src[ne:]
+	for k, i := range b.indexes {
+		if int(i) >= n || k > 0 && i <= b.indexes[k-1] {
+			return Block{}, fmt.Errorf("packer: block: %w: exception index %d", ErrCorrupt, i)
+		}
+	}
+	return b, nil
+}
+
+// Stats returns the statistics of b.
+func (b Block) Stats() BlockStats { return b.stats }
+
+// Len returns the number of values in b.
+func (b Block) Len() int { return b.stats.Len }
+
+// At returns the i-th value of b without decoding the other ones.
+func (b Block) At(i int) uint64 {
+	if i < 0 || i >= b.stats.Len {
+		panic(fmt.Sprintf("packer: block index %d out of range [0:%d]", i, b.stats.Len))
+	}
+	width := b.stats.Width
+	r := getBits(b.values, i*width, width)
+	k := sort.Search(len(b.indexes), func(k int) bool { return int(b.indexes[k]) >= i })
+	if k < len(b.indexes) && int(b.indexes[k]) == i {
+		ewidth := b.stats.ExceptionWidth
+		r |= getBits(b.exceptions, k*ewidth, ewidth) << width
+	}
+	return b.stats.Min + r
+}
+
+// AppendValues appends the values of b to dst and returns the extended slice.
+func (b Block) AppendValues(dst []uint64) []uint64 {
+	n := len(dst)
+	width := b.stats.Width
+	for i := 0; i < b.stats.Len; i++ {
+		dst = append(dst, b.stats.Min+getBits(b.values, i*width, width))
+	}
+	ewidth := b.stats.ExceptionWidth
+	for k, i := range b.indexes {
+		dst[n+int(i)] += getBits(b.exceptions, k*ewidth, ewidth) << width
+	}
+	return dst
+}
+
+// putBits sets the n bits of buf starting at bit pos to the lowest n bits of x.
+// The bits of buf must be unset.
+func putBits(buf []byte, pos, n int, x uint64) {
+	if n < 64 {
+		x &= 1<<n - 1
+	}
+	for n > 0 {
+		off := pos % 8
+		buf[pos/8] |= byte(x << off)
+		m := 8 - off
+		if m > n {
+			m = n
+		}
+		x >>= m
+		pos += m
+		n -= m
+	}
+}
+
+// getBits returns the n bits of buf starting at bit pos.
+func getBits(buf []byte, pos, n int) uint64 {
+	var x uint64
+	for shift := 0; shift < n; {
+		off := pos % 8
+		x |= uint64(buf[pos/8]>>off) << shift
+		m := 8 - off
+		shift += m
+		pos += m
+	}
+	if n < 64 {
+		x &= 1<<n - 1
+	}
+	return x
+}
@@ -0,0 +1,116 @@
+package packer
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestBlock(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	narrow := make([]uint64, BlockLen)
+	for i := range narrow {
+		narrow[i] = 1000 + uint64(r.Intn(16))
+	}
+	outliers := append([]uint64(nil), narrow...)
+	outliers[3] = 1 << 40
+	outliers[100] = math.MaxUint64
+	// Exceptions are larger than the full width for most values.
+	wide := make([]uint64, 16)
+	for i := range wide[1:] {
+		wide[i+1] = math.MaxUint64 - uint64(i)
+	}
+
+	for _, tc := range []struct {
+		label  string
+		values []uint64
+		stats  BlockStats
+	}{
+		{"single", []uint64{42},
+			BlockStats{Len: 1, Min: 42, Size: 6}},
+		{"constant", []uint64{7, 7, 7},
+			BlockStats{Len: 3, Min: 7, Size: 6}},
+		{"narrow", narrow,
+			BlockStats{Len: BlockLen, Min: 1000, Width: 4, Size: 7 + 64}},
+		{"outliers", outliers,
+			BlockStats{Len: BlockLen, Min: 1000, Width: 4, Exceptions: 2, ExceptionWidth: 60, Size: 7 + 64 + 2 + 15}},
+		{"full width", []uint64{0, math.MaxUint64},
+			BlockStats{Len: 2, Exceptions: 1, ExceptionWidth: 64, Size: 5 + 1 + 8}},
+		{"full width values", wide,
+			BlockStats{Len: 16, Width: 64, Size: 5 + 16*8}},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			buf := EncodeBlock([]byte{1}, tc.values)
+			b, err := ParseBlock(buf[1:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := b.Stats(), tc.stats; got != want {
+				t.Fatalf("got %+v; want %+v", got, want)
+			}
+			if got, want := b.Stats().Size, len(buf)-1; got != want {
+				t.Fatalf("got size %d; want %d", got, want)
+			}
+			if got := b.AppendValues(nil); !reflect.DeepEqual(got, tc.values) {
+				t.Fatalf("got %v; want %v", got, tc.values)
+			}
+			for i, want := range tc.values {
+				if got := b.At(i); got != want {
+					t.Fatalf("value %d: got %d; want %d", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBlocks(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	values := make([]uint64, 3*BlockLen+10)
+	for i := range values {
+		values[i] = uint64(r.Intn(1 << 20))
+		if i%50 == 0 {
+			values[i] = r.Uint64()
+		}
+	}
+	buf := EncodeBlocks(nil, values)
+	got, err := DecodeBlocks(nil, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, values) {
+		t.Fatal("values differ")
+	}
+
+	got, err = DecodeBlocks(nil, buf[:len(buf)-1])
+	if !errors.Is(err, ErrShortBuffer) {
+		t.Fatalf("got %v; want %v", err, ErrShortBuffer)
+	}
+	if len(got) != 3*BlockLen {
+		t.Fatalf("got %d values; want %d", len(got), 3*BlockLen)
+	}
+}
+
+func TestParseBlockError(t *testing.T) {
+	for _, tc := range []struct {
+		label string
+		in    []byte
+		err   error
+	}{
+		{"header", []byte{0, 0, 0}, ErrShortBuffer},
+		{"min", []byte{0, 0, 0, 0}, ErrShortBuffer},
+		{"values", []byte{1, 8, 0, 0, 0, 1}, ErrShortBuffer},
+		{"count", []byte{BlockLen, 0, 0, 0, 0}, ErrCorrupt},
+		{"width", []byte{0, 60, 5, 0, 0}, ErrCorrupt},
+		{"exceptions", []byte{0, 0, 1, 2, 0, 0, 0, 0}, ErrCorrupt},
+		{"exception index", []byte{1, 0, 1, 1, 0, 2, 1}, ErrCorrupt},
+		{"exception order", []byte{2, 0, 1, 2, 0, 1, 1, 3}, ErrCorrupt},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			if _, err := ParseBlock(tc.in); !errors.Is(err, tc.err) {
+				t.Fatalf("got %v; want %v", err, tc.err)
+			}
+		})
+	}
+}
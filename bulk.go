@@ -0,0 +1,41 @@
+package packer
+
+import "github.com/pierrec/packer/internal/packuint"
+
+// PackUint64s appends the packed values of src to dst and returns the extended buffer.
+func PackUint64s(dst []byte, src []uint64) []byte {
+	return packuint.PackUint64s(dst, src)
+}
+
+// UnpackUint64s appends the values packed in src to dst and returns the extended slice
+// along with the number of bytes read.
+//
+// It returns an error wrapping ErrShortBuffer if the last value of src is truncated,
+// in which case the values before it are still appended to dst.
+func UnpackUint64s(dst []uint64, src []byte) ([]uint64, int, error) {
+	dst, n := packuint.UnpackUint64s(dst, src)
+	if n < len(src) {
+		_, err := packedLen64(src[n:])
+		return dst, n, err
+	}
+	return dst, n, nil
+}
+
+// PackUint32s appends the packed values of src to dst and returns the extended buffer.
+func PackUint32s(dst []byte, src []uint32) []byte {
+	return packuint.PackUint32s(dst, src)
+}
+
+// UnpackUint32s appends the values packed in src to dst and returns the extended slice
+// along with the number of bytes read.
+//
+// It returns an error wrapping ErrShortBuffer if the last value of src is truncated,
+// in which case the values before it are still appended to dst.
+func UnpackUint32s(dst []uint32, src []byte) ([]uint32, int, error) {
+	dst, n := packuint.UnpackUint32s(dst, src)
+	if n < len(src) {
+		_, err := packedLen32(src[n:])
+		return dst, n, err
+	}
+	return dst, n, nil
+}
@@ -0,0 +1,48 @@
+package packer
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestUnpackUint64s(t *testing.T) {
+	values := []uint64{0, 1, 1 << 10, 0xF0F0F0F0, math.MaxUint64}
+	buf := PackUint64s(nil, values)
+	got, n, err := UnpackUint64s(nil, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(buf) || !reflect.DeepEqual(got, values) {
+		t.Fatalf("got %v in %d bytes; want %v in %d", got, n, values, len(buf))
+	}
+
+	got, n, err = UnpackUint64s(nil, buf[:len(buf)-1])
+	if !errors.Is(err, ErrShortBuffer) {
+		t.Fatalf("got %v; want %v", err, ErrShortBuffer)
+	}
+	if want := values[:len(values)-1]; n != len(buf)-9 || !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v in %d bytes; want %v in %d", got, n, want, len(buf)-9)
+	}
+}
+
+func TestUnpackUint32s(t *testing.T) {
+	values := []uint32{0, 1, 1 << 10, 0xF0F0F0, math.MaxUint32}
+	buf := PackUint32s(nil, values)
+	got, n, err := UnpackUint32s(nil, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(buf) || !reflect.DeepEqual(got, values) {
+		t.Fatalf("got %v in %d bytes; want %v in %d", got, n, values, len(buf))
+	}
+
+	got, n, err = UnpackUint32s(nil, buf[:len(buf)-1])
+	if !errors.Is(err, ErrShortBuffer) {
+		t.Fatalf("got %v; want %v", err, ErrShortBuffer)
+	}
+	if want := values[:len(values)-1]; n != len(buf)-5 || !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v in %d bytes; want %v in %d", got, n, want, len(buf)-5)
+	}
+}
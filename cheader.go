@@ -0,0 +1,141 @@
+package packer
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// GenCHeader generates a C header giving access to the members of the struct s packed as defined by GenPackedStruct.
+//
+// The packed type is defined as an uintN_t and its fields are accessed with static inline functions
+// named after the Go methods and prefixed with the type name, using shift and mask macros.
+// C bitfields are not used as their ordering is compiler specific.
+//
+// Example:
+//  type Header struct{
+//    version [4]uint
+//    Flag    bool
+//  }
+// results in the following type:
+//  typedef uint8_t Header;
+// with functions:
+//  uint64_t Header_version(Header x)
+//  bool Header_Flag(Header x)
+//  Header *Header_versionSet(Header *x, uint64_t v)
+//  Header *Header_FlagSet(Header *x, bool v)
+func GenCHeader(w io.Writer, config *Config, s interface{}) error {
+	l, err := config.LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+
+	config.init()
+
+	type _Field struct {
+		Name  string // function name suffix
+		Macro string // macros prefix
+		Out   string // returned C type
+		Bool  bool
+		Shift int
+		Mask  string
+	}
+	prefix := strings.ToUpper(l.Name)
+	literal := func(v uint64) string { return fmt.Sprintf("UINT%d_C(0x%X)", l.Size, v) }
+	var fields []_Field
+	for _, f := range l.Fields {
+		if f.Reserved() {
+			continue
+		}
+		fields = append(fields, _Field{
+			Name:  f.Name,
+			Macro: prefix + "_" + strings.ToUpper(f.Name),
+			Out:   cTypes[f.Kind],
+			Bool:  f.Kind == reflect.Bool,
+			Shift: f.Offset,
+			Mask:  literal(uint64(1)<<f.Bits - 1),
+		})
+	}
+
+	err = cTemplate.Execute(w, struct {
+		Header   string
+		Comments string
+		TypeName string
+		Prefix   string
+		Type     string
+		Size     int
+		Reserved string
+		Fields   []_Field
+	}{
+		config.TopComments,
+		layoutComments(l),
+		l.Name,
+		prefix,
+		fmt.Sprintf("uint%d_t", l.Size),
+		l.Size / 8,
+		literal(l.reserved() & (^uint64(0) >> (64 - l.Size))),
+		fields,
+	})
+	if err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+// cTypes maps the kinds of the fields to their C type.
+var cTypes = map[reflect.Kind]string{
+	reflect.Bool:   "bool",
+	reflect.Int:    "int64_t",
+	reflect.Int8:   "int8_t",
+	reflect.Int16:  "int16_t",
+	reflect.Int32:  "int32_t",
+	reflect.Int64:  "int64_t",
+	reflect.Uint:   "uint64_t",
+	reflect.Uint8:  "uint8_t",
+	reflect.Uint16: "uint16_t",
+	reflect.Uint32: "uint32_t",
+	reflect.Uint64: "uint64_t",
+}
+
+var cTemplate = template.Must(template.New("C header gen").Parse(cSource))
+
+const cSource = `{{.Header}}
+#ifndef PACKER_{{.Prefix}}_H
+#define PACKER_{{.Prefix}}_H
+
+#include <stdbool.h>
+#include <stdint.h>
+
+{{.Comments -}}
+typedef {{.Type}} {{.TypeName}};
+
+#define {{.Prefix}}_SIZE {{.Size}}
+#define {{.Prefix}}_RESERVED_MASK {{.Reserved}}
+_Static_assert(sizeof({{.TypeName}}) == {{.Prefix}}_SIZE, "{{.TypeName}} must be {{.Size}} bytes");
+{{range .Fields}}
+#define {{.Macro}}_SHIFT {{.Shift}}
+#define {{.Macro}}_MASK {{.Mask}}
+{{- end}}
+
+// Getters.
+{{range .Fields -}}
+static inline {{.Out}} {{$.TypeName}}_{{.Name}}({{$.TypeName}} x) {
+{{- if .Bool}}
+	return (x >> {{.Macro}}_SHIFT & {{.Macro}}_MASK) != 0;
+{{- else}}
+	return ({{.Out}})(x >> {{.Macro}}_SHIFT & {{.Macro}}_MASK);
+{{- end}}
+}
+{{end}}
+// Setters.
+{{range .Fields -}}
+static inline {{$.TypeName}} *{{$.TypeName}}_{{.Name}}Set({{$.TypeName}} *x, {{.Out}} v) {
+	*x = ({{$.TypeName}})((*x & ~({{.Macro}}_MASK << {{.Macro}}_SHIFT)) | (({{$.TypeName}})v & {{.Macro}}_MASK) << {{.Macro}}_SHIFT);
+	return x;
+}
+{{end}}
+#endif // PACKER_{{.Prefix}}_H
+`
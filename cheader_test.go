@@ -0,0 +1,69 @@
+package packer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type Ints struct {
+	Int8  int8
+	Int16 int16
+	Int32 int32
+}
+
+func TestGenCHeader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "packer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, s := range []interface{}{Ints{}, Version3{}} {
+		buf := new(bytes.Buffer)
+		if err := GenCHeader(buf, goldenConfig(), s); err != nil {
+			t.Fatal(err)
+		}
+		name := reflect.TypeOf(s).Name() + ".h"
+		checkGolden(t, name, buf.Bytes())
+		if err := ioutil.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("no C compiler: only the golden files are checked")
+	}
+	checkLang(t, map[string]string{
+		"ints": `	Ints i = 0;
+	Ints_Int32Set(Ints_Int16Set(Ints_Int8Set(&i, -3), -1000), -100000);
+	printf("%llx %d %d %d\n", (unsigned long long)i, Ints_Int8(i), Ints_Int16(i), Ints_Int32(i));`,
+		"version3": `	Version3 v = 0;
+	Version3_ChecksumSet(Version3_LenSet(Version3_flagSet(Version3_versionSet(&v, 9), true), 1000), 0xDEADBEEF);
+	printf("%llx %llu %d %lld %x\n", (unsigned long long)v,
+		(unsigned long long)Version3_version(v), Version3_flag(v), (long long)Version3_Len(v), Version3_Checksum(v));`,
+		"reserved": `	Version3_flagSet(&v, false);
+	printf("%d %llx\n", Version3_flag(v), (unsigned long long)(v & VERSION3_RESERVED_MASK));`,
+	}, nil, func(body string) []byte {
+		prog := "#include <stdio.h>\n#include \"Ints.h\"\n#include \"Version3.h\"\n\nint main(void) {\n" + body + "\n\treturn 0;\n}\n"
+		if err := ioutil.WriteFile(filepath.Join(dir, "main.c"), []byte(prog), 0644); err != nil {
+			t.Fatal(err)
+		}
+		bin := filepath.Join(dir, "main")
+		cmd := exec.Command(cc, "-std=c11", "-Wall", "-Wextra", "-Werror", "-pedantic", "-o", bin, "main.c")
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%v: %s", err, out)
+		}
+		out, err := exec.Command(bin).Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	})
+}
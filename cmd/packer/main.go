@@ -0,0 +1,246 @@
+// Command packer generates the code of packed structs defined in Go source files.
+//
+// Usage:
+//
+//	packer -type T [flags] file.go...
+//	packer -type T [flags] file.ksy...
+//	packer -type T [flags] diagram.txt...
+//	packer -type T [flags] schema.yaml...
+//
+// The named struct types are packed as described by packer.GenPackedStruct.
+// Several types can be generated in a single Go file, other languages taking only one.
+// The code is generated in Go by default, or in the language set with -lang.
+// The documentation of the layouts can also be generated with -lang diagram, md or svg.
+// The source files are type checked together, so types they depend on must be
+// defined in one of them or imported.
+//
+// The layouts can also be read from Kaitai Struct files, as described by packer.LayoutFromKaitai,
+// if all the files have the .ksy extension. The packed type name is then the camel cased id
+// of the file, or its -orig-id. Conversely, -lang ksy exports the layout in a .ksy file.
+//
+// The layouts can be read from RFC style ASCII bit diagrams, as described by
+// packer.LayoutFromDiagram, if all the files have the .txt extension. Each file defines
+// the type named at the same position in the -type list.
+//
+// Finally, the layouts can be read from YAML or JSON schemas, as described by
+// packer.LayoutFromSchema, if all the files have the .yaml, .yml or .json extension.
+// The packed type name is set by their name key. The schema of these files is
+// published in layout.schema.json at the root of the repository.
+//
+// With -check, no code is generated. Instead, the code that would be written to the -o file
+// is compared with its content and packer exits with status 1 if the file is out of date,
+// typically because the source struct was modified without running go generate again.
+// The generated Go code also holds the fingerprint of the layouts so that tests can detect
+// this, see packer.CheckFingerprint.
+//
+// With -compat, no code is generated. Instead, the layouts of the named types are compared
+// with the ones defined in the given file, typically an older version of the same source,
+// and packer exits with status 1 if a change is breaking. This is meant to be run in CI:
+//
+//	git show origin/main:gen.go > /tmp/gen.go
+//	packer -type Header -compat /tmp/gen.go gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pierrec/packer"
+)
+
+// generators lists the code generators by language.
+var generators = map[string]func(io.Writer, *packer.Config, interface{}) error{
+	"go":   packer.GenPackedStruct,
+	"c":    packer.GenCHeader,
+	"rust": packer.GenRust,
+	"ts":   packer.GenTypeScript,
+	"lua":  packer.GenLuaDissector,
+	"ksy":  packer.GenKaitai,
+	// Documentation.
+	"diagram": packer.GenDiagram,
+	"md":      packer.GenMarkdown,
+	"svg":     packer.GenSVG,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("packer", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typeNames := fs.String("type", "", "comma-separated list of type names; must be set")
+	output := fs.String("o", "", "output file name; default stdout")
+	pkgName := fs.String("pkg", "", "package name of the generated code; default the package of the source files")
+	lang := fs.String("lang", "go", "language of the generated code: go, c, rust, ts, lua (Wireshark dissector), ksy (Kaitai Struct), or documentation: diagram (ASCII), md or svg")
+	bigEndian := fs.Bool("be", false, "read and write packed values as big endian bytes instead of little endian")
+	msbFirst := fs.Bool("msb", false, "pack the first field in the most significant bits")
+	reorder := fs.String("reorder", "none", "reorder the fields: none, size (keep the smallest type) or align (byte align them)")
+	sql := fs.Bool("sql", false, "generate the database/sql Scanner and driver.Valuer interfaces")
+	check := fs.Bool("check", false, "compare the generated code with the content of the -o file and fail if it is out of date")
+	compat := fs.String("compat", "", "compare the layouts with the ones defined in the given Go file and fail on breaking changes")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "usage: packer -type T [flags] file.go...\n")
+		_, _ = fmt.Fprintf(stderr, "       packer -type T [flags] file.ksy...\n")
+		_, _ = fmt.Fprintf(stderr, "       packer -type T [flags] diagram.txt...\n")
+		_, _ = fmt.Fprintf(stderr, "       packer -type T [flags] schema.yaml...\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *typeNames == "" || fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	fail := func(err error) int {
+		_, _ = fmt.Fprintf(stderr, "packer: %v\n", err)
+		return 1
+	}
+
+	names := strings.Split(*typeNames, ",")
+	pkg, layouts, err := loadLayouts(fs.Args(), names)
+	if err != nil {
+		return fail(err)
+	}
+
+	config := &packer.Config{SQL: *sql, MSBFirst: *msbFirst}
+	for r := packer.ReorderNone; r <= packer.ReorderAlign; r++ {
+		if r.String() == *reorder {
+			config.Reorder = r
+			break
+		}
+		if r == packer.ReorderAlign {
+			return fail(fmt.Errorf("unsupported reordering %q", *reorder))
+		}
+	}
+
+	if *compat != "" {
+		_, olds, err := loadLayouts([]string{*compat}, names)
+		if err != nil {
+			return fail(err)
+		}
+		status := 0
+		for i, l := range layouts {
+			// Compare the layouts as generated.
+			lo, err := config.LayoutOf(olds[i])
+			if err != nil {
+				return fail(err)
+			}
+			ln, err := config.LayoutOf(l)
+			if err != nil {
+				return fail(err)
+			}
+			changes, err := packer.CompareLayouts(lo, ln)
+			if err != nil {
+				return fail(err)
+			}
+			for _, c := range changes {
+				_, _ = fmt.Fprintf(stdout, "%s: %s\n", l.Name, c)
+			}
+			if packer.Breaking(changes) {
+				status = 1
+			}
+		}
+		return status
+	}
+
+	if len(layouts) > 1 && *lang != "go" {
+		return fail(fmt.Errorf("only one type can be generated at a time in %s", *lang))
+	}
+	if *pkgName == "" {
+		*pkgName = pkg
+	}
+	if *pkgName == "" && *lang == "go" {
+		return fail(fmt.Errorf("the package name must be set with -pkg"))
+	}
+	config.PkgName = *pkgName
+	// Layouts imported with their most significant bits first are big endian.
+	if *bigEndian || layouts[0].MSBFirst {
+		config.ByteOrder = binary.BigEndian
+	}
+
+	// The header is the same with or without -check so that the code can be compared.
+	cmd := []string{filepath.Base(os.Args[0])}
+	for _, arg := range args {
+		if name := strings.TrimLeft(arg, "-"); name != "check" && !strings.HasPrefix(name, "check=") {
+			cmd = append(cmd, arg)
+		}
+	}
+	config.TopComments = fmt.Sprintf(packer.TopComments, strings.Join(cmd, " "))
+
+	gen, ok := generators[*lang]
+	if !ok {
+		return fail(fmt.Errorf("unsupported language %q", *lang))
+	}
+	if len(layouts) > 1 {
+		gen = func(w io.Writer, config *packer.Config, _ interface{}) error {
+			g := packer.NewGenerator(config)
+			for _, l := range layouts {
+				g.Add(l)
+			}
+			return g.Generate(w)
+		}
+	}
+	buf := new(bytes.Buffer)
+	if err := gen(buf, config, layouts[0]); err != nil {
+		return fail(err)
+	}
+	code := buf.Bytes()
+	if *lang == "go" {
+		// Unlike the Generator used for several types, GenPackedStruct does not format its code.
+		if code, err = format.Source(code); err != nil {
+			return fail(err)
+		}
+	}
+
+	switch {
+	case *check:
+		if *output == "" {
+			return fail(fmt.Errorf("the file to check must be set with -o"))
+		}
+		if err := checkOutput(*output, code); err != nil {
+			return fail(err)
+		}
+	case *output == "":
+		if _, err := stdout.Write(code); err != nil {
+			return fail(err)
+		}
+	default:
+		if err := ioutil.WriteFile(*output, code, 0644); err != nil {
+			return fail(err)
+		}
+	}
+	return 0
+}
+
+// checkOutput returns an error locating the first difference between the content of the named file
+// and the generated code.
+func checkOutput(name string, code []byte) error {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, code) {
+		return nil
+	}
+	have := strings.Split(string(data), "\n")
+	want := strings.Split(string(code), "\n")
+	for i := 0; ; i++ {
+		switch {
+		case i == len(have) || i == len(want):
+			return fmt.Errorf("%s is out of date: has %d lines instead of %d", name, len(have), len(want))
+		case have[i] != want[i]:
+			return fmt.Errorf("%s is out of date at line %d:\n-%s\n+%s", name, i+1, have[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pierrec/packer"
+)
+
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		label  string
+		args   []string
+		status int
+		out    string
+	}{
+		{"generate",
+			[]string{"-type", "Version", "testdata/v3.go"},
+			0, "func (x Version) Checksum() Checksum { return Checksum(x >> 32 & 0xFFFFFFFF) }"},
+		{"generate package",
+			[]string{"-type", "Version", "-pkg", "other", "testdata/v2.go"},
+			0, "package other\n"},
+		{"C header",
+			[]string{"-type", "Version", "-lang", "c", "testdata/v2.go"},
+			0, "static inline int64_t Version_Len(Version x) {"},
+		{"Lua dissector",
+			[]string{"-type", "Version", "-lang", "lua", "-msb", "-be", "testdata/v2.go"},
+			0, `  version = ProtoField.uint32("version.version", "version", base.DEC, nil, 0xF0000000),`},
+		{"Kaitai Struct export",
+			[]string{"-type", "Version", "-lang", "ksy", "testdata/v2.go"},
+			0, "  bit-endian: le\n"},
+		{"Kaitai Struct import",
+			[]string{"-type", "Header", "-pkg", "proto", "testdata/header.ksy"},
+			0, "func (x Header) PayloadLength() uint16 { return uint16(x & 0xFFF) }"},
+		{"Kaitai Struct import without package",
+			[]string{"-type", "Header", "testdata/header.ksy"},
+			1, ""},
+		{"bit diagram",
+			[]string{"-type", "Header", "-pkg", "proto", "testdata/header.txt"},
+			0, "func (x Header) PayloadLength() uint16 { return uint16(x & 0xFFF) }"},
+		{"schema",
+			[]string{"-type", "Header", "-pkg", "proto", "testdata/header.yaml"},
+			0, "\tHeaderVersionV2 uint8 = 2\n"},
+		{"several types",
+			[]string{"-type", "Header,Other", "-pkg", "proto", "testdata/header.txt", "testdata/header.txt"},
+			0, "type Other uint16\n"},
+		{"several types in C",
+			[]string{"-type", "Header,Other", "-lang", "c", "testdata/header.txt", "testdata/header.txt"},
+			1, ""},
+		{"mixed sources",
+			[]string{"-type", "Header", "-pkg", "proto", "testdata/v2.go", "testdata/header.txt"},
+			1, ""},
+		{"reorder",
+			[]string{"-type", "Version", "-reorder", "size", "testdata/v2.go"},
+			0, "// Version is defined as follow, its fields being reordered:\n"},
+		{"unknown reordering",
+			[]string{"-type", "Version", "-reorder", "random", "testdata/v2.go"},
+			1, ""},
+		{"Markdown",
+			[]string{"-type", "Version", "-lang", "md", "testdata/v2.go"},
+			0, "| 5-20 | `Len` | `int` | `0x001FFFE0` |"},
+		{"unknown language",
+			[]string{"-type", "Version", "-lang", "cobol", "testdata/v2.go"},
+			1, ""},
+		{"unknown type",
+			[]string{"-type", "Header", "testdata/v2.go"},
+			1, ""},
+		{"compatible",
+			[]string{"-type", "Version", "-compat", "testdata/v2.go", "testdata/v2.go"},
+			0, ""},
+		{"breaking",
+			[]string{"-type", "Version", "-compat", "testdata/v2.go", "testdata/v3.go"},
+			1, "Version: Len: moved from bit 5 to bit 12 (breaking)\n"},
+		{"breaking with msb",
+			[]string{"-type", "Version", "-msb", "-compat", "testdata/v2.go", "testdata/v3.go"},
+			1, "Version: version: moved from bit 28 to bit 60 (breaking)\n"},
+		{"usage",
+			[]string{"testdata/v2.go"},
+			2, ""},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			stdout := new(bytes.Buffer)
+			stderr := new(bytes.Buffer)
+			status := run(tc.args, stdout, stderr)
+			if status != tc.status {
+				t.Fatalf("got status %d; want %d: %s", status, tc.status, stderr)
+			}
+			if got := stdout.String(); !strings.Contains(got, tc.out) {
+				t.Fatalf("got %q; want %q", got, tc.out)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "packer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "v3.go")
+	out := filepath.Join(dir, "gen.go")
+	data, err := ioutil.ReadFile("testdata/v3.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(src, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"-type", "Version", "-o", out, src}
+	check := func() (int, string) {
+		stderr := new(bytes.Buffer)
+		status := run(append([]string{"-check"}, args...), new(bytes.Buffer), stderr)
+		return status, stderr.String()
+	}
+
+	if status, _ := check(); status != 1 {
+		t.Fatalf("missing file: got status %d; want 1", status)
+	}
+	if status := run(args, new(bytes.Buffer), new(bytes.Buffer)); status != 0 {
+		t.Fatalf("got status %d; want 0", status)
+	}
+	if status, msg := check(); status != 0 {
+		t.Fatalf("got status %d; want 0: %s", status, msg)
+	}
+	// The flag is not part of the header, whatever its form.
+	stderr := new(bytes.Buffer)
+	if status := run(append([]string{"-check=true"}, args...), new(bytes.Buffer), stderr); status != 0 {
+		t.Fatalf("-check=true: got status %d; want 0: %s", status, stderr)
+	}
+	if code, err := ioutil.ReadFile(out); err != nil {
+		t.Fatal(err)
+	} else if fmted, err := format.Source(code); err != nil || !bytes.Equal(code, fmted) {
+		t.Fatalf("generated code is not formatted: %v", err)
+	}
+
+	// The struct changed but not the generated code.
+	data = bytes.Replace(data, []byte("Len      [16]int"), []byte("Len      [15]int"), 1)
+	if err := ioutil.WriteFile(src, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	status, msg := check()
+	if want := "is out of date at line 12:\n-//\tLen       16\n+//\tLen       15\n"; status != 1 || !strings.Contains(msg, want) {
+		t.Fatalf("got status %d %q; want 1 %q", status, msg, want)
+	}
+
+	args = []string{"-type", "Version", src}
+	if status, _ := check(); status != 1 {
+		t.Fatalf("no output file: got status %d; want 1", status)
+	}
+}
+
+// Checksum is a named field type local to the package of the struct.
+type Checksum uint32
+
+type fingerprinted struct {
+	version  [4]uint
+	bytes    [2]byte
+	Checksum [16]Checksum
+}
+
+func TestFingerprint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "packer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "fp.go")
+	data := []byte(`package fpcheck
+
+type Checksum uint32
+
+type fingerprinted struct {
+	version  [4]uint
+	bytes    [2]byte
+	Checksum [16]Checksum
+}
+`)
+	if err := ioutil.WriteFile(src, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, layouts, err := loadLayouts([]string{src}, []string{"fingerprinted"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := packer.LayoutOf(fingerprinted{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := layouts[0].Fingerprint(), want.Fingerprint(); got != want {
+		t.Fatalf("got fingerprint %s from the sources; want %s", got, want)
+	}
+}
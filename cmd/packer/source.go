@@ -0,0 +1,223 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/pierrec/packer"
+)
+
+// loadLayouts type checks the given Go files and returns the package name
+// as well as the layouts of the named struct types.
+// Kaitai Struct files, bit diagrams or schemas are loaded instead if all the files have
+// the .ksy, .txt or .yaml, .yml and .json extensions, in which case the package name is empty.
+func loadLayouts(files []string, names []string) (string, []*packer.Layout, error) {
+	switch {
+	case hasExt(files, ".ksy"):
+		layouts, err := loadNamed(files, names, packer.LayoutFromKaitai)
+		return "", layouts, err
+	case hasExt(files, ".yaml", ".yml", ".json"):
+		layouts, err := loadNamed(files, names, packer.LayoutFromSchema)
+		return "", layouts, err
+	case hasExt(files, ".txt"):
+		layouts, err := loadDiagrams(files, names)
+		return "", layouts, err
+	}
+	fset := token.NewFileSet()
+	var asts []*ast.File
+	for _, f := range files {
+		file, err := parser.ParseFile(fset, f, nil, 0)
+		if err != nil {
+			return "", nil, err
+		}
+		asts = append(asts, file)
+	}
+	if len(asts) == 0 {
+		return "", nil, fmt.Errorf("no Go file")
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Only the struct definitions matter: ignore errors elsewhere.
+		Error: func(error) {},
+	}
+	pkg, _ := conf.Check(asts[0].Name.Name, fset, asts, nil)
+
+	var layouts []*packer.Layout
+	for _, name := range names {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return "", nil, fmt.Errorf("type %s not found", name)
+		}
+		l, err := layoutOf(pkg, obj)
+		if err != nil {
+			return "", nil, err
+		}
+		layouts = append(layouts, l)
+	}
+	return pkg.Name(), layouts, nil
+}
+
+// hasExt reports whether files all have one of the extensions exts.
+func hasExt(files []string, exts ...string) bool {
+	for _, f := range files {
+		ok := false
+		for _, ext := range exts {
+			ok = ok || filepath.Ext(f) == ext
+		}
+		if !ok {
+			return false
+		}
+	}
+	return len(files) > 0
+}
+
+// loadNamed returns the layouts of the named types defined in files, read with load.
+func loadNamed(files []string, names []string, load func(io.Reader) (*packer.Layout, error)) ([]*packer.Layout, error) {
+	byName := map[string]*packer.Layout{}
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		l, err := load(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		byName[l.Name] = l
+	}
+	var layouts []*packer.Layout
+	for _, name := range names {
+		l, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found", name)
+		}
+		layouts = append(layouts, l)
+	}
+	return layouts, nil
+}
+
+// loadDiagrams returns the layouts of the types defined by the bit diagrams, one per file,
+// named in the same order.
+func loadDiagrams(files []string, names []string) ([]*packer.Layout, error) {
+	if len(files) != len(names) {
+		return nil, fmt.Errorf("%d types for %d bit diagrams", len(names), len(files))
+	}
+	var layouts []*packer.Layout
+	for i, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		l, err := packer.LayoutFromDiagram(names[i], f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		layouts = append(layouts, l)
+	}
+	return layouts, nil
+}
+
+// layoutOf is the go/types equivalent of packer.LayoutOf.
+func layoutOf(pkg *types.Package, obj *types.TypeName) (*packer.Layout, error) {
+	werrf := func(f string, err error) error { return fmt.Errorf("packer: type %s.%s: %w", obj.Name(), f, err) }
+
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("packer: type %s: %w", obj.Name(), packer.ErrNotAStruct)
+	}
+	qualifier := func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	}
+
+	var fields []packer.Field
+	for i, n := 0, st.NumFields(); i < n; i++ {
+		field := st.Field(i)
+		if field.Embedded() {
+			return nil, werrf(field.Name(), packer.ErrEmbeddedField)
+		}
+
+		out := field.Type()
+		var bits int
+		switch typ := out.Underlying().(type) {
+		case *types.Basic:
+			switch typ.Kind() {
+			case types.Bool:
+				bits = 1
+			case types.Int8, types.Uint8:
+				bits = 8
+			case types.Int16, types.Uint16:
+				bits = 16
+			case types.Int32, types.Uint32:
+				bits = 32
+			default:
+				return nil, werrf(field.Name(), packer.ErrFieldBadType)
+			}
+		case *types.Array:
+			out = typ.Elem()
+			bits = int(typ.Len())
+		default:
+			return nil, werrf(field.Name(), packer.ErrFieldBadType)
+		}
+		var pkgPath string
+		if named, ok := out.(*types.Named); ok {
+			if p := named.Obj().Pkg(); p != nil && p != pkg {
+				pkgPath = p.Path()
+			}
+		}
+		fields = append(fields, packer.Field{
+			Name:    field.Name(),
+			Type:    types.TypeString(out, qualifier),
+			PkgPath: pkgPath,
+			Kind:    kindOf(out),
+			Bits:    bits,
+			Tag:     reflect.StructTag(st.Tag(i)),
+		})
+	}
+	return packer.NewLayout(obj.Name(), fields)
+}
+
+// kindOf returns the reflect.Kind of the basic type underlying t, or reflect.Invalid.
+func kindOf(t types.Type) reflect.Kind {
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return reflect.Invalid
+	}
+	switch b.Kind() {
+	case types.Bool:
+		return reflect.Bool
+	case types.Int:
+		return reflect.Int
+	case types.Int8:
+		return reflect.Int8
+	case types.Int16:
+		return reflect.Int16
+	case types.Int32:
+		return reflect.Int32
+	case types.Int64:
+		return reflect.Int64
+	case types.Uint:
+		return reflect.Uint
+	case types.Uint8:
+		return reflect.Uint8
+	case types.Uint16:
+		return reflect.Uint16
+	case types.Uint32:
+		return reflect.Uint32
+	case types.Uint64:
+		return reflect.Uint64
+	}
+	return reflect.Invalid
+}
@@ -0,0 +1,7 @@
+package testdata
+
+type Version struct {
+	version [4]uint
+	flag    bool
+	Len     [16]int
+}
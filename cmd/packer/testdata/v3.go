@@ -0,0 +1,12 @@
+package testdata
+
+type Version struct {
+	version  [4]uint
+	flag     bool
+	_        [7]int // reserved
+	Len      [16]int
+	_        [4]int // reserved
+	Checksum [32]Checksum
+}
+
+type Checksum uint32
@@ -0,0 +1,180 @@
+package packer
+
+import "fmt"
+
+// ChangeKind identifies a difference between two layouts.
+type ChangeKind int
+
+// Layout changes reported by CompareLayouts.
+const (
+	FieldAdded      ChangeKind = iota // field only found in the new layout
+	FieldRemoved                      // field only found in the old layout
+	FieldMoved                        // field offset changed
+	FieldGrown                        // field uses more bits
+	FieldShrunk                       // field uses less bits
+	FieldSignedness                   // field changed from signed to unsigned or vice versa
+	FieldRetyped                      // field type changed, signedness excepted
+	ReservedReused                    // field uses previously reserved bits
+	SizeGrown                         // underlying type is larger
+	SizeShrunk                        // underlying type is smaller
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case FieldAdded:
+		return "added"
+	case FieldRemoved:
+		return "removed"
+	case FieldMoved:
+		return "moved"
+	case FieldGrown:
+		return "grown"
+	case FieldShrunk:
+		return "shrunk"
+	case FieldSignedness:
+		return "signedness changed"
+	case FieldRetyped:
+		return "type changed"
+	case ReservedReused:
+		return "reuses reserved bits"
+	case SizeGrown:
+		return "size grown"
+	case SizeShrunk:
+		return "size shrunk"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change describes a difference between two layouts.
+type Change struct {
+	Kind     ChangeKind
+	Field    string // field name, empty for size changes
+	Old, New Field  // field in each layout, when applicable
+	Breaking bool   // values encoded with the old layout do not decode correctly with the new one
+}
+
+func (c Change) String() string {
+	var s string
+	switch c.Kind {
+	case FieldMoved:
+		s = fmt.Sprintf("%s: moved from bit %d to bit %d", c.Field, c.Old.Offset, c.New.Offset)
+	case FieldGrown, FieldShrunk:
+		s = fmt.Sprintf("%s: %s from %d to %d bits", c.Field, c.Kind, c.Old.Bits, c.New.Bits)
+	case FieldSignedness, FieldRetyped:
+		s = fmt.Sprintf("%s: %s from %s to %s", c.Field, c.Kind, c.Old.Type, c.New.Type)
+	case FieldAdded, FieldRemoved, ReservedReused:
+		s = fmt.Sprintf("%s: %s", c.Field, c.Kind)
+	default:
+		s = c.Kind.String()
+	}
+	if c.Breaking {
+		return s + " (breaking)"
+	}
+	return s + " (compatible)"
+}
+
+// CompareLayouts reports the differences between the old and new layouts
+// and whether values encoded with old still decode correctly with new.
+//
+// old and new are either structs as accepted by GenPackedStruct or *Layout values.
+// Fields are matched by name. Bits that were reserved or unused in the old layout are
+// assumed to be zero, so new fields using them are compatible.
+// Growing a signed field using all the bits of its type is breaking, its negative values
+// being sign extended by the old getter only.
+// The layouts are compared as is: use Config.LayoutOf to compare them as generated.
+func CompareLayouts(old, new interface{}) ([]Change, error) {
+	lo, err := LayoutOf(old)
+	if err != nil {
+		return nil, err
+	}
+	ln, err := LayoutOf(new)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bits holding data and bits explicitly reserved in the old layout.
+	var used, reserved uint64
+	for _, f := range lo.Fields {
+		if f.Reserved() {
+			reserved |= f.Mask()
+		} else {
+			used |= f.Mask()
+		}
+	}
+
+	var changes []Change
+	for _, fo := range lo.Fields {
+		if fo.Reserved() {
+			continue
+		}
+		fn, ok := ln.Field(fo.Name)
+		if !ok {
+			changes = append(changes, Change{Kind: FieldRemoved, Field: fo.Name, Old: fo})
+			continue
+		}
+		c := Change{Field: fo.Name, Old: fo, New: fn}
+		switch {
+		case fn.Offset != fo.Offset:
+			c.Kind = FieldMoved
+			c.Breaking = true
+			changes = append(changes, c)
+		case fn.Bits < fo.Bits:
+			c.Kind = FieldShrunk
+			c.Breaking = true
+			changes = append(changes, c)
+		case fn.Bits > fo.Bits:
+			c.Kind = FieldGrown
+			// The extra bits must not hold data of another field, and negative values
+			// sign extended by the old getter are not by the new one.
+			c.Breaking = fn.Mask()&^fo.Mask()&used != 0 || fo.signExtended()
+			changes = append(changes, c)
+		}
+		if fo.Kind == fn.Kind && fo.Type == fn.Type {
+			continue
+		}
+		c = Change{Field: fo.Name, Old: fo, New: fn}
+		if fo.Signed() != fn.Signed() {
+			c.Kind = FieldSignedness
+			c.Breaking = true
+		} else {
+			c.Kind = FieldRetyped
+		}
+		changes = append(changes, c)
+	}
+
+	for _, fn := range ln.Fields {
+		if fn.Reserved() {
+			continue
+		}
+		if _, ok := lo.Field(fn.Name); ok {
+			continue
+		}
+		c := Change{Kind: FieldAdded, Field: fn.Name, New: fn}
+		switch m := fn.Mask(); {
+		case m&used != 0:
+			// Old values would be read from the field.
+			c.Breaking = true
+		case m&reserved != 0:
+			c.Kind = ReservedReused
+		}
+		changes = append(changes, c)
+	}
+
+	switch {
+	case ln.Size > lo.Size:
+		changes = append(changes, Change{Kind: SizeGrown})
+	case ln.Size < lo.Size:
+		changes = append(changes, Change{Kind: SizeShrunk, Breaking: true})
+	}
+	return changes, nil
+}
+
+// Breaking reports whether any of the changes is breaking.
+func Breaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package packer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompareLayouts(t *testing.T) {
+	type (
+		Reserved struct {
+			X [8]uint8
+			_ [8]uint8
+		}
+		Split struct {
+			X [4]uint8
+			Y [4]uint8
+		}
+		Byte struct {
+			X [8]uint8
+		}
+		Nibble struct {
+			X [4]uint8
+		}
+		Signed struct {
+			X [8]int8
+		}
+		Wide struct {
+			X [4]uint16
+		}
+		WideByte struct {
+			X [8]uint16
+		}
+		SignedWide struct {
+			X [16]int16
+		}
+		SignedNibble struct {
+			X [4]int8
+		}
+		SignedByte struct {
+			X [5]int8
+		}
+		Full struct {
+			X [8]uint8
+			Y [8]uint8
+		}
+		Other struct {
+			X [4]uint8
+			Z [4]uint8
+		}
+	)
+	type change struct {
+		Kind     ChangeKind
+		Field    string
+		Breaking bool
+	}
+	for _, tc := range []struct {
+		label    string
+		old, new interface{}
+		changes  []change
+	}{
+		{"same", Version2{}, Version2{}, nil},
+		{"Version1 to Version2", Version1{}, Version2{}, []change{
+			{FieldAdded, "Len", false},
+			{SizeGrown, "", false},
+		}},
+		{"Version2 to Version3", Version2{}, Version3{}, []change{
+			{FieldMoved, "Len", true},
+			{FieldAdded, "Checksum", false},
+			{SizeGrown, "", false},
+		}},
+		{"Version3 to Version2", Version3{}, Version2{}, []change{
+			{FieldMoved, "Len", true},
+			{FieldRemoved, "Checksum", false},
+			{SizeShrunk, "", true},
+		}},
+		{"reserved reused", Reserved{}, Full{}, []change{
+			{ReservedReused, "Y", false},
+		}},
+		{"shrunk", Byte{}, Nibble{}, []change{
+			{FieldShrunk, "X", true},
+		}},
+		{"grown", Nibble{}, Byte{}, []change{
+			{FieldGrown, "X", false},
+		}},
+		{"grown over field", Split{}, Byte{}, []change{
+			{FieldGrown, "X", true},
+			{FieldRemoved, "Y", false},
+		}},
+		{"signedness", Byte{}, Signed{}, []change{
+			{FieldSignedness, "X", true},
+		}},
+		{"retyped", Nibble{}, Wide{}, []change{
+			{FieldRetyped, "X", false},
+		}},
+		{"signed grown", Signed{}, SignedWide{}, []change{
+			{FieldGrown, "X", true},
+			{FieldRetyped, "X", false},
+			{SizeGrown, "", false},
+		}},
+		{"partial signed grown", SignedNibble{}, SignedByte{}, []change{
+			{FieldGrown, "X", false},
+		}},
+		{"grown over field retyped", Split{}, WideByte{}, []change{
+			{FieldGrown, "X", true},
+			{FieldRetyped, "X", false},
+			{FieldRemoved, "Y", false},
+		}},
+		{"added over removed", Split{}, Other{}, []change{
+			{FieldRemoved, "Y", false},
+			{FieldAdded, "Z", true},
+		}},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			changes, err := CompareLayouts(tc.old, tc.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []change
+			for _, c := range changes {
+				got = append(got, change{c.Kind, c.Field, c.Breaking})
+			}
+			if !reflect.DeepEqual(got, tc.changes) {
+				t.Fatalf("got %v; want %v", changes, tc.changes)
+			}
+			want := false
+			for _, c := range tc.changes {
+				want = want || c.Breaking
+			}
+			if got := Breaking(changes); got != want {
+				t.Fatalf("got breaking %v; want %v", got, want)
+			}
+		})
+	}
+
+	if _, err := CompareLayouts(0, Version1{}); err == nil {
+		t.Fatal("expected error not found")
+	}
+}
@@ -1,6 +1,7 @@
 package packer
 
 import (
+	"encoding/binary"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,11 +15,22 @@ const TopComments = "// Code generated by `%s`. DO NOT EDIT.\n"
 
 // Config is used to define some parameters used when generating code.
 type Config struct {
-	TopComments string // header clause (default=TopComments)
-	PkgName     string // package name used for the generated file (default="")
+	TopComments string  // header clause (default=TopComments)
+	PkgName     string  // package name used for the generated file (default="")
+	SQL         bool    // generate the database/sql Scanner and driver.Valuer interfaces (default=false)
+	MSBFirst    bool    // pack the first field in the most significant bits (default=false)
+	Reorder     Reorder // reorder the fields to keep the backing type small or align them (default=ReorderNone)
+	TypePkg     string  // import path of the packed type of tables generated in another package (default="")
+
+	// ByteOrder is the order of the bytes of packed values read or written as bytes
+	// by the generated code of other languages (default=binary.LittleEndian).
+	ByteOrder binary.ByteOrder
 }
 
 func (c *Config) init() {
+	if c.ByteOrder == nil {
+		c.ByteOrder = binary.LittleEndian
+	}
 	if c.TopComments == "" {
 		c.TopComments = TopComments
 	}
@@ -28,3 +40,24 @@ func (c *Config) init() {
 		c.TopComments = fmt.Sprintf(c.TopComments, strings.Join(cmd, " "))
 	}
 }
+
+// LayoutOf returns the layout of s, a struct or a *Layout, as generated with c:
+// with the field order and bit order it defines.
+func (c *Config) LayoutOf(s interface{}) (*Layout, error) {
+	l, err := LayoutOf(s)
+	if err != nil {
+		return nil, err
+	}
+	r, err := l.reorder(c.Reorder)
+	if err != nil {
+		return nil, fmt.Errorf("packer: type %s: %w", l.Name, err)
+	}
+	l = r
+	if c.MSBFirst && !l.MSBFirst {
+		if l.fixed() {
+			return nil, fmt.Errorf("packer: type %s: %w", l.Name, ErrFieldOffset)
+		}
+		l = l.reverse()
+	}
+	return l, nil
+}
@@ -0,0 +1,106 @@
+package packer
+
+import (
+	"fmt"
+
+	"github.com/pierrec/packer/internal/packuint"
+)
+
+// packedLen64 returns the number of bytes of the uint64 packed at the start of buf.
+func packedLen64(buf []byte) (int, error) {
+	if len(buf) == 0 {
+		return 0, fmt.Errorf("packer: uint64: %w: no bitmap", ErrShortBuffer)
+	}
+	n := packuint.PackedLenUint64(buf[0])
+	if len(buf) < n {
+		return 0, fmt.Errorf("packer: uint64: %w: %d bytes for %d", ErrShortBuffer, len(buf), n)
+	}
+	return n, nil
+}
+
+// packedLen32 returns the number of bytes of the uint32 packed at the start of buf.
+func packedLen32(buf []byte) (int, error) {
+	if len(buf) == 0 {
+		return 0, fmt.Errorf("packer: uint32: %w: no bitmap", ErrShortBuffer)
+	}
+	n := packuint.PackedLenUint32(buf[0])
+	if len(buf) < n {
+		return 0, fmt.Errorf("packer: uint32: %w: %d bytes for %d", ErrShortBuffer, len(buf), n)
+	}
+	return n, nil
+}
+
+// DecodeUint64 unpacks the value packed at the start of buf, as done by PackUint64,
+// and returns it along with the number of bytes read.
+//
+// It returns an error wrapping ErrShortBuffer if buf does not hold as many bytes as its bitmap requires.
+func DecodeUint64(buf []byte) (x uint64, n int, err error) {
+	if n, err = packedLen64(buf); err != nil {
+		return
+	}
+	return packuint.UnpackUint64(buf[0], buf[1:n]), n, nil
+}
+
+// DecodeUint32 unpacks the value packed at the start of buf, as done by PackUint32,
+// and returns it along with the number of bytes read.
+//
+// It returns an error wrapping ErrShortBuffer if buf does not hold as many bytes as its bitmap requires.
+func DecodeUint32(buf []byte) (x uint32, n int, err error) {
+	if n, err = packedLen32(buf); err != nil {
+		return
+	}
+	return packuint.UnpackUint32(buf[0], buf[1:n]), n, nil
+}
+
+// DecodeInt64 is like DecodeUint64 for values packed with PackInt64.
+func DecodeInt64(buf []byte) (x int64, n int, err error) {
+	u, n, err := DecodeUint64(buf)
+	return packuint.Unzigzag64(u), n, err
+}
+
+// DecodeInt32 is like DecodeUint32 for values packed with PackInt32.
+func DecodeInt32(buf []byte) (x int32, n int, err error) {
+	u, n, err := DecodeUint32(buf)
+	return packuint.Unzigzag32(u), n, err
+}
+
+// DecodeUint64Strict is like DecodeUint64 but only accepts the encoding produced by PackUint64,
+// so that a value has a single valid encoding.
+//
+// It returns an error wrapping ErrNonCanonical if a byte flagged in the bitmap is zero.
+func DecodeUint64Strict(buf []byte) (x uint64, n int, err error) {
+	if n, err = packedLen64(buf); err != nil {
+		return
+	}
+	if x, err = packuint.UnpackUint64Strict(buf[0], buf[1:n]); err != nil {
+		return 0, 0, fmt.Errorf("packer: uint64: %w", ErrNonCanonical)
+	}
+	return
+}
+
+// DecodeUint32Strict is like DecodeUint32 but only accepts the encoding produced by PackUint32,
+// so that a value has a single valid encoding.
+//
+// It returns an error wrapping ErrNonCanonical if a nibble flagged in the bitmap is zero
+// or if the unused nibble of the last byte is not.
+func DecodeUint32Strict(buf []byte) (x uint32, n int, err error) {
+	if n, err = packedLen32(buf); err != nil {
+		return
+	}
+	if x, err = packuint.UnpackUint32Strict(buf[0], buf[1:n]); err != nil {
+		return 0, 0, fmt.Errorf("packer: uint32: %w", ErrNonCanonical)
+	}
+	return
+}
+
+// DecodeInt64Strict is like DecodeUint64Strict for values packed with PackInt64.
+func DecodeInt64Strict(buf []byte) (x int64, n int, err error) {
+	u, n, err := DecodeUint64Strict(buf)
+	return packuint.Unzigzag64(u), n, err
+}
+
+// DecodeInt32Strict is like DecodeUint32Strict for values packed with PackInt32.
+func DecodeInt32Strict(buf []byte) (x int32, n int, err error) {
+	u, n, err := DecodeUint32Strict(buf)
+	return packuint.Unzigzag32(u), n, err
+}
@@ -0,0 +1,122 @@
+package packer
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDecodeUint64(t *testing.T) {
+	values := []uint64{0, 1, 1 << 10, 0xF0F0F0F0, math.MaxUint64}
+	var buf []byte
+	for _, x := range values {
+		b := make([]byte, 9)
+		buf = append(buf, b[:PackUint64(b, x)]...)
+	}
+	for i, b := 0, buf; len(b) > 0; i++ {
+		x, n, err := DecodeUint64(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := x, values[i]; got != want {
+			t.Fatalf("got %d; want %d", got, want)
+		}
+		b = b[n:]
+	}
+
+	last := buf[len(buf)-9:]
+	for i := 0; i < len(last); i++ {
+		_, _, err := DecodeUint64(last[:i])
+		if !errors.Is(err, ErrShortBuffer) {
+			t.Fatalf("%d bytes: got %v; want %v", i, err, ErrShortBuffer)
+		}
+	}
+}
+
+func TestDecodeUint32(t *testing.T) {
+	values := []uint32{0, 1, 1 << 10, 0xF0F0F0, math.MaxUint32}
+	var buf []byte
+	for _, x := range values {
+		b := make([]byte, 5)
+		buf = append(buf, b[:PackUint32(b, x)]...)
+	}
+	for i, b := 0, buf; len(b) > 0; i++ {
+		x, n, err := DecodeUint32(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := x, values[i]; got != want {
+			t.Fatalf("got %d; want %d", got, want)
+		}
+		b = b[n:]
+	}
+
+	last := buf[len(buf)-5:]
+	for i := 0; i < len(last); i++ {
+		_, _, err := DecodeUint32(last[:i])
+		if !errors.Is(err, ErrShortBuffer) {
+			t.Fatalf("%d bytes: got %v; want %v", i, err, ErrShortBuffer)
+		}
+	}
+}
+
+func TestDecodeInt(t *testing.T) {
+	buf := make([]byte, 9)
+	for _, x := range []int64{0, -1, 1, math.MinInt64, math.MaxInt64} {
+		n := PackInt64(buf, x)
+		got, m, err := DecodeInt64(buf[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != x || m != n {
+			t.Fatalf("got %d (%d bytes); want %d (%d bytes)", got, m, x, n)
+		}
+	}
+	for _, x := range []int32{0, -1, 1, math.MinInt32, math.MaxInt32} {
+		n := PackInt32(buf, x)
+		got, m, err := DecodeInt32(buf[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != x || m != n {
+			t.Fatalf("got %d (%d bytes); want %d (%d bytes)", got, m, x, n)
+		}
+	}
+	if _, _, err := DecodeInt64(nil); !errors.Is(err, ErrShortBuffer) {
+		t.Fatalf("got %v; want %v", err, ErrShortBuffer)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	buf := make([]byte, 9)
+	n := PackUint64(buf, 0xF0F0F0F0)
+	if x, m, err := DecodeUint64Strict(buf[:n]); err != nil || x != 0xF0F0F0F0 || m != n {
+		t.Fatalf("got %d, %d, %v; want %d, %d", x, m, err, 0xF0F0F0F0, n)
+	}
+	n = PackInt32(buf, -3200)
+	if x, m, err := DecodeInt32Strict(buf[:n]); err != nil || x != -3200 || m != n {
+		t.Fatalf("got %d, %d, %v; want %d, %d", x, m, err, -3200, n)
+	}
+
+	for _, tc := range []struct {
+		label string
+		fn    func([]byte) error
+		in    []byte
+		err   error
+	}{
+		{"uint64 zero byte", func(b []byte) error { _, _, err := DecodeUint64Strict(b); return err },
+			[]byte{0x80, 0}, ErrNonCanonical},
+		{"int64 short", func(b []byte) error { _, _, err := DecodeInt64Strict(b); return err },
+			[]byte{0xC0, 1}, ErrShortBuffer},
+		{"uint32 unused nibble", func(b []byte) error { _, _, err := DecodeUint32Strict(b); return err },
+			[]byte{0x80, 0x21}, ErrNonCanonical},
+		{"int32 zero nibble", func(b []byte) error { _, _, err := DecodeInt32Strict(b); return err },
+			[]byte{0xC0, 0x10}, ErrNonCanonical},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			if err := tc.fn(tc.in); !errors.Is(err, tc.err) {
+				t.Fatalf("got %v; want %v", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package packer
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// LayoutFromDiagram returns the layout of the type name described by the first
+// RFC style ASCII bit diagram read from r, such as:
+//   0                   1
+//   0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//  |Version|  IHL  |Type of Service|
+//  +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//
+// Each bit is two characters wide and fields are delimited by | characters.
+// The diagram starts at the first border line (+-+-...) and ends after the last one,
+// the text around it, including the bit numbers, is ignored.
+// Rows are read in order and the first field is packed in the most significant bits.
+// Rows may span several lines with the same field boundaries, the labels being joined.
+// A field without label starting a row, and whose border above is left blank,
+// continues the last field of the previous row.
+//
+// Fields are named after their label in camel case, labeled Reserved or Unused fields are reserved.
+// Their type is bool for a single bit and the smallest unsigned integer holding their bits otherwise.
+//
+// Malformed diagrams are reported with a SourceError.
+func LayoutFromDiagram(name string, r io.Reader) (*Layout, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseDiagram(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("packer: diagram: %w", err)
+	}
+	l, err := NewLayout(name, fields)
+	if err != nil {
+		return nil, err
+	}
+	return l.reverse(), nil
+}
+
+// reservedLabels lists the labels, in lower case, of the fields that are reserved.
+var reservedLabels = map[string]bool{
+	"reserved": true, "rsvd": true, "unused": true, "must be zero": true, "mbz": true,
+}
+
+// diagramRow is a row of fields of a bit diagram, which may span several lines.
+type diagramRow struct {
+	border []rune // border line above the row
+	first  int    // index of the first line
+	lines  [][]rune
+}
+
+// parseDiagram returns the fields of the first bit diagram found in s.
+func parseDiagram(s string) ([]Field, error) {
+	lines := strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+	errorf := func(i, col int, err error, format string, args ...interface{}) error {
+		return &SourceError{Line: i + 1, Col: col + 1, Err: fmt.Errorf("%w: %s", err, fmt.Sprintf(format, args...))}
+	}
+
+	// The diagram starts with a border whose column is used for all lines.
+	start, left := -1, 0
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if strings.HasPrefix(trimmed, "+-") {
+			start, left = i, len([]rune(line))-len([]rune(trimmed))
+			break
+		}
+	}
+	if start < 0 {
+		return nil, errorf(0, 0, ErrEmptyStruct, "no bit diagram")
+	}
+
+	// Split the diagram into rows.
+	var rows []*diagramRow
+	var border []rune   // last border line
+	var row *diagramRow // current row, not yet closed by a border
+	for i := start; i < len(lines); i++ {
+		line := []rune(strings.TrimRight(lines[i], " \t"))
+		if len(line) <= left || strings.TrimSpace(string(line[:left])) != "" || line[left] != '+' && line[left] != '|' {
+			if row != nil {
+				return nil, errorf(i, left, ErrSyntax, "missing border below row")
+			}
+			break
+		}
+		if line[left] == '+' {
+			for j := left; j < len(line); j++ {
+				if c := line[j]; c != '+' && c != '-' && c != ' ' {
+					return nil, errorf(i, j, ErrSyntax, "unexpected %q in border", c)
+				}
+			}
+			border, row = line, nil
+			continue
+		}
+
+		if len(line) > len(border) {
+			return nil, errorf(i, len(border), ErrSyntax, "row wider than the border above")
+		}
+		if line[len(line)-1] != '|' {
+			return nil, errorf(i, len(line), ErrSyntax, "unterminated row")
+		}
+		if row == nil {
+			row = &diagramRow{border: border, first: i}
+			rows = append(rows, row)
+		} else {
+			// The fields of a row spanning several lines must have the same boundaries.
+			prev := row.lines[len(row.lines)-1]
+			for j := left; j < len(line) || j < len(prev); j++ {
+				if j >= len(line) || j >= len(prev) || (line[j] == '|') != (prev[j] == '|') {
+					return nil, errorf(i, j, ErrSyntax, "field boundary does not match the line above")
+				}
+			}
+		}
+		row.lines = append(row.lines, line)
+	}
+	if row != nil {
+		return nil, errorf(len(lines), left, ErrSyntax, "missing border below row")
+	}
+
+	// Fields of each row.
+	var fields []Field
+	var size int
+	names := map[string]bool{}
+	for _, row := range rows {
+		line := row.lines[0]
+		for p, q := left, left+1; q < len(line); q++ {
+			if line[q] != '|' {
+				continue
+			}
+			if (q-p)%2 != 0 {
+				return nil, errorf(row.first, q, ErrSyntax, "field boundary not aligned on a bit")
+			}
+			bits := (q - p) / 2
+
+			// The label may be split over the lines of the row.
+			var label []string
+			li, lcol := row.first, p+1
+			for k, line := range row.lines {
+				text := string(line[p+1 : q])
+				if t := strings.TrimSpace(text); t != "" {
+					if len(label) == 0 {
+						li, lcol = row.first+k, p+1+len([]rune(text))-len([]rune(strings.TrimLeft(text, " ")))
+					}
+					label = append(label, t)
+				}
+			}
+
+			switch {
+			case len(label) > 0:
+				name, ok := fieldName(strings.Join(label, " "))
+				if !ok {
+					return nil, errorf(li, lcol, ErrInvalidValue, "label %q is not a valid field name", strings.Join(label, " "))
+				}
+				if names[name] && name != "_" {
+					return nil, errorf(li, lcol, ErrInvalidValue, "duplicate field %s", name)
+				}
+				names[name] = true
+				fields = append(fields, Field{Name: name, Bits: bits})
+			case p == left && len(fields) > 0 && !strings.ContainsRune(string(row.border[p+1:q]), '-'):
+				// Continuation of the last field of the previous row.
+				fields[len(fields)-1].Bits += bits
+			default:
+				return nil, errorf(row.first, p+1, ErrInvalidValue, "unnamed field")
+			}
+			if size += bits; size > 64 {
+				return nil, errorf(row.first, p+1, ErrStructOverflow, "%d bits", size)
+			}
+			p = q
+		}
+	}
+	if len(fields) == 0 {
+		return nil, errorf(start, left, ErrEmptyStruct, "no field")
+	}
+	for i, f := range fields {
+		f.Kind = defaultKind(f.Bits)
+		f.Type = f.Kind.String()
+		fields[i] = f
+	}
+	return fields, nil
+}
+
+// fieldName converts a diagram label into a field name: Type of Service becomes TypeOfService.
+func fieldName(label string) (string, bool) {
+	words := strings.FieldsFunc(label, func(c rune) bool { return !unicode.IsLetter(c) && !unicode.IsDigit(c) })
+	if reservedLabels[strings.ToLower(strings.Join(words, " "))] {
+		return "_", true
+	}
+	for i, w := range words {
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		words[i] = string(r)
+	}
+	name := strings.Join(words, "")
+	return name, token.IsIdentifier(name)
+}
+
+// GenDiagram generates the RFC style ASCII bit diagram of the struct s packed as defined by GenPackedStruct.
+//
+// The packed value is drawn in rows of up to 32 bits, starting with its most significant bits
+// as bit 0 of the diagram. Reserved and unused bits are labeled (reserved) and (unused).
+// Labels that do not fit in their field are truncated and listed below the diagram.
+// The result can be read back with LayoutFromDiagram.
+func GenDiagram(w io.Writer, config *Config, s interface{}) error {
+	l, err := config.LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+
+	config.init()
+
+	if _, err := io.WriteString(w, diagram(l)); err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+// span is a range of bits drawn in the documentation of a layout.
+type span struct {
+	field  *Field // nil for unused bits
+	hi, lo int    // most and least significant bits
+	cont   bool   // the span continues the one ending the previous row
+}
+
+func (s span) bits() int { return s.hi - s.lo + 1 }
+
+// label returns the name of the span.
+func (s span) label() string {
+	switch {
+	case s.field == nil:
+		return "(unused)"
+	case s.field.Reserved():
+		return "(reserved)"
+	}
+	return s.field.Name
+}
+
+// bitRange returns the bits of the span as in 5-11.
+func (s span) bitRange() string {
+	if s.hi == s.lo {
+		return fmt.Sprint(s.lo)
+	}
+	return fmt.Sprintf("%d-%d", s.lo, s.hi)
+}
+
+// layoutSpans returns the spans covering all the bits of l, starting with the most significant ones.
+func layoutSpans(l *Layout) []span {
+	fields := make([]*Field, len(l.Fields))
+	for i := range l.Fields {
+		fields[i] = &l.Fields[i]
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Offset > fields[j].Offset })
+	var spans []span
+	hi := l.Size - 1
+	for _, f := range fields {
+		if end := f.Offset + f.Bits - 1; end < hi {
+			spans = append(spans, span{hi: hi, lo: end + 1})
+		}
+		spans = append(spans, span{field: f, hi: f.Offset + f.Bits - 1, lo: f.Offset})
+		hi = f.Offset - 1
+	}
+	if hi >= 0 {
+		spans = append(spans, span{hi: hi, lo: 0})
+	}
+	return spans
+}
+
+// diagramRowBits returns the number of bits per row of the diagrams of l.
+func diagramRowBits(l *Layout) int {
+	if l.Size < 32 {
+		return l.Size
+	}
+	return 32
+}
+
+// diagramRows splits the spans of l into rows of width bits.
+func diagramRows(l *Layout, width int) [][]span {
+	var rows [][]span
+	var row []span
+	for _, s := range layoutSpans(l) {
+		for {
+			// Least significant bit of the current row.
+			lo := (s.hi / width) * width
+			if s.lo >= lo {
+				row = append(row, s)
+				break
+			}
+			row = append(row, span{field: s.field, hi: s.hi, lo: lo, cont: s.cont})
+			rows = append(rows, row)
+			row = nil
+			s = span{field: s.field, hi: lo - 1, lo: s.lo, cont: true}
+		}
+		if s.lo%width == 0 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	return rows
+}
+
+// diagramLabels lists shorter labels, by order of preference, for reserved and unused bits.
+var diagramLabels = map[string][]string{
+	"(reserved)": {"rsvd", "R"},
+	"(unused)":   {"unused", "U"},
+}
+
+// diagram returns the ASCII bit diagram of l.
+func diagram(l *Layout) string {
+	const indent = "   "
+	width := diagramRowBits(l)
+	buf := new(strings.Builder)
+
+	// Bit numbers.
+	tens := new(strings.Builder)
+	for i := 0; i < width; i++ {
+		if i%10 == 0 {
+			fmt.Fprintf(tens, " %d", i/10)
+		} else {
+			tens.WriteString("  ")
+		}
+	}
+	fmt.Fprintf(buf, "%s%s\n%s", indent, strings.TrimRight(tens.String(), " "), indent)
+	for i := 0; i < width; i++ {
+		fmt.Fprintf(buf, " %d", i%10)
+	}
+	buf.WriteString("\n")
+
+	var legend []string
+	for _, row := range diagramRows(l, width) {
+		// Border above the row, left blank above continued fields.
+		buf.WriteString(indent)
+		for _, s := range row {
+			if s.cont {
+				fmt.Fprintf(buf, "+%s", strings.Repeat(" ", 2*s.bits()-1))
+			} else {
+				fmt.Fprintf(buf, "+%s-", strings.Repeat("-+", s.bits()-1))
+			}
+		}
+		buf.WriteString("+\n")
+
+		buf.WriteString(indent + "|")
+		for _, s := range row {
+			n := 2*s.bits() - 1
+			var label string
+			if !s.cont {
+				label = s.label()
+				for _, short := range diagramLabels[label] {
+					if utf8.RuneCountInString(label) <= n {
+						break
+					}
+					label = short
+				}
+				// Truncate by runes, which are the columns of the diagram.
+				if r := []rune(label); len(r) > n {
+					legend = append(legend, fmt.Sprintf("%s%s: %s", indent, string(r[:n]), label))
+					label = string(r[:n])
+				}
+			}
+			pad := n - utf8.RuneCountInString(label)
+			fmt.Fprintf(buf, "%s%s%s|", strings.Repeat(" ", pad/2), label, strings.Repeat(" ", pad-pad/2))
+		}
+		buf.WriteString("\n")
+	}
+	fmt.Fprintf(buf, "%s+%s-+\n", indent, strings.Repeat("-+", width-1))
+
+	if len(legend) > 0 {
+		buf.WriteString("\n")
+		buf.WriteString(strings.Join(legend, "\n"))
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
@@ -0,0 +1,125 @@
+package packer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLayoutFromDiagram(t *testing.T) {
+	const ipv4 = `The Internet Header Format (RFC 791):
+
+    0                   1                   2                   3
+    0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |Version|  IHL  |Type of Service|          Total Length         |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+   |         Identification        |R|D|M|     Fragment Offset     |
+   | (16 bits)                     | |F|F|                         |
+   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+
+Followed by the TTL.
+`
+	l, err := LayoutFromDiagram("IPv4", strings.NewReader(ipv4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name != "IPv4" || l.Size != 64 || !l.MSBFirst {
+		t.Fatalf("got %s %d %v; want IPv4 64 true", l.Name, l.Size, l.MSBFirst)
+	}
+	type field struct {
+		Name         string
+		Kind         reflect.Kind
+		Offset, Bits int
+	}
+	var got []field
+	for _, f := range l.Fields {
+		got = append(got, field{f.Name, f.Kind, f.Offset, f.Bits})
+	}
+	want := []field{
+		{"Version", reflect.Uint8, 60, 4},
+		{"IHL", reflect.Uint8, 56, 4},
+		{"TypeOfService", reflect.Uint8, 48, 8},
+		{"TotalLength", reflect.Uint16, 32, 16},
+		{"Identification16Bits", reflect.Uint16, 16, 16},
+		{"R", reflect.Bool, 15, 1},
+		{"DF", reflect.Bool, 14, 1},
+		{"MF", reflect.Bool, 13, 1},
+		{"FragmentOffset", reflect.Uint16, 0, 13},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
+
+func TestLayoutFromDiagramContinuation(t *testing.T) {
+	const diagram = `
++-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+|     Kind      |   Reserved    |
++-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+|           Timestamp           |
++                               +
+|                               |
++-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+`
+	l, err := LayoutFromDiagram("Event", strings.NewReader(diagram))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The 48 bits are packed in the most significant bits of an uint64.
+	if len(l.Fields) != 3 || !l.Fields[1].Reserved() {
+		t.Fatalf("got %+v; want Kind, _ and Timestamp", l.Fields)
+	}
+	if f, _ := l.Field("Timestamp"); f.Bits != 32 || f.Offset != 16 || f.Kind != reflect.Uint32 {
+		t.Fatalf("got %+v; want 32 bits at offset 16", f)
+	}
+}
+
+func TestLayoutFromDiagramError(t *testing.T) {
+	const border = "  +-+-+-+-+-+-+-+-+\n"
+	for _, tc := range []struct {
+		label string
+		in    string
+		err   error
+		msg   string
+	}{
+		{"no diagram", "text", ErrEmptyStruct, "1:1: empty struct: no bit diagram"},
+		{"no field", border, ErrEmptyStruct, "1:3: empty struct: no field"},
+		{"border", "  +-+-+=+-+\n", ErrSyntax, `1:8: syntax error: unexpected '=' in border`},
+		{"missing border", border + "  |   A   |   B   |\nText", ErrSyntax,
+			"3:3: syntax error: missing border below row"},
+		{"missing border at end", border + "  |   A   |   B   |", ErrSyntax,
+			"3:3: syntax error: missing border below row"},
+		{"unterminated", border + "  |   A   |   B    \n" + border, ErrSyntax,
+			"2:16: syntax error: unterminated row"},
+		{"wide", border + "  |   A   |   B     |\n" + border, ErrSyntax,
+			"2:20: syntax error: row wider than the border above"},
+		{"misaligned", border + "  |   A  |    B   |\n" + border, ErrSyntax,
+			"2:10: syntax error: field boundary not aligned on a bit"},
+		{"boundaries", border + "  |   A   |   B   |\n  |     |     B   |\n" + border, ErrSyntax,
+			"3:9: syntax error: field boundary does not match the line above"},
+		{"unnamed", border + "  |   A   |       |\n" + border, ErrInvalidValue,
+			"2:12: invalid value: unnamed field"},
+		{"name", border + "  |   A   |  2B   |\n" + border, ErrInvalidValue,
+			`2:14: invalid value: label "2B" is not a valid field name`},
+		{"duplicate", border + "  |   A   |   a   |\n" + border, ErrInvalidValue,
+			"2:15: invalid value: duplicate field A"},
+		{"overflow", strings.Repeat("+-", 66) + "+\n|" + strings.Repeat(" ", 64) + "A|" + strings.Repeat(" ", 64) + "B|\n" +
+			strings.Repeat("+-", 66) + "+\n", ErrStructOverflow, "2:68: struct overflows uint64: 66 bits"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			_, err := LayoutFromDiagram("T", strings.NewReader(tc.in))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got %v; want %v", err, tc.err)
+			}
+			var serr *SourceError
+			if !errors.As(err, &serr) {
+				t.Fatalf("%v: not a SourceError", err)
+			}
+			if got, want := serr.Error(), tc.msg; got != want {
+				t.Fatalf("got %q; want %q", got, want)
+			}
+		})
+	}
+}
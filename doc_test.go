@@ -0,0 +1,102 @@
+package packer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenDoc(t *testing.T) {
+	for _, tc := range []struct {
+		ext string
+		gen func(io.Writer, *Config, interface{}) error
+	}{
+		{".txt", GenDiagram},
+		{".md", GenMarkdown},
+		{".svg", GenSVG},
+	} {
+		for _, s := range []interface{}{Ints{}, Version2{}, Version3{}} {
+			buf := new(bytes.Buffer)
+			if err := tc.gen(buf, goldenConfig(), s); err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, reflect.TypeOf(s).Name()+tc.ext, buf.Bytes())
+			if tc.ext == ".svg" {
+				// Check that the SVG is well formed.
+				dec := xml.NewDecoder(buf)
+				for {
+					if _, err := dec.Token(); err == io.EOF {
+						break
+					} else if err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGenDiagramRoundTrip(t *testing.T) {
+	for _, s := range []interface{}{Ints{}, Version2{}, Version3{}, Small{}} {
+		want, err := LayoutOf(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		if err := GenDiagram(buf, goldenConfig(), s); err != nil {
+			t.Fatal(err)
+		}
+		got, err := LayoutFromDiagram(want.Name, buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Unused bits are read as reserved fields and some labels are truncated:
+		// only compare the used bits.
+		var gotBits, wantBits []int
+		for _, f := range got.Fields {
+			if !f.Reserved() {
+				gotBits = append(gotBits, f.Offset, f.Bits)
+			}
+		}
+		for i := len(want.Fields) - 1; i >= 0; i-- {
+			if f := want.Fields[i]; !f.Reserved() {
+				wantBits = append(wantBits, f.Offset, f.Bits)
+			}
+		}
+		if !reflect.DeepEqual(gotBits, wantBits) {
+			t.Fatalf("%s: got %v; want %v", want.Name, gotBits, wantBits)
+		}
+	}
+}
+
+func TestGenDiagramTruncate(t *testing.T) {
+	// Labels wider than their field are truncated by runes and listed in the legend.
+	type Unicode struct {
+		Größe [2]uint
+		Flag  [14]uint
+	}
+	buf := new(bytes.Buffer)
+	if err := GenDiagram(buf, goldenConfig(), Unicode{}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !utf8.ValidString(out) {
+		t.Fatalf("invalid UTF-8 in diagram:\n%s", out)
+	}
+	for _, want := range []string{"|Grö|", "Grö: Größe"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in diagram:\n%s", want, out)
+		}
+	}
+	l, err := LayoutFromDiagram("Unicode", buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := l.Fields[len(l.Fields)-1]; f.Bits != 2 {
+		t.Errorf("got %d bits for %s; want 2", f.Bits, f.Name)
+	}
+}
@@ -0,0 +1,69 @@
+package packer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Fingerprint returns a short hash of the layout: its name, size, bit order and fields
+// with their types, positions, default and enum values.
+// Field types are identified the same way whether the layout comes from LayoutOf
+// or from parsed sources, see typeID.
+//
+// The code generated by GenPackedStruct holds the fingerprint of its struct layout
+// in the <Type>Fingerprint constant, see CheckFingerprint.
+func (l *Layout) Fingerprint() string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s %d %v\n", l.Name, l.Size, l.MSBFirst)
+	for _, f := range l.Fields {
+		_, _ = fmt.Fprintf(h, "%s %s %v %d %d %v %q", f.Name, f.typeID(), f.Kind, f.Offset, f.Bits, f.Fixed, f.Default)
+		for _, e := range f.Enum {
+			_, _ = fmt.Fprintf(h, " %s=%d", e.Name, e.Value)
+		}
+		_, _ = fmt.Fprintln(h)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// typeID returns the identity of the field type: its kind if it is a predeclared type,
+// otherwise its import path and unqualified name, the import path being empty for types
+// defined in the package of the struct. reflect and go/types do not qualify nor name them alike.
+func (f Field) typeID() string {
+	name := f.Type
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	switch {
+	case name == f.Kind.String(),
+		name == "byte" && f.Kind == reflect.Uint8,
+		name == "rune" && f.Kind == reflect.Int32:
+		return f.Kind.String()
+	}
+	return f.PkgPath + "." + name
+}
+
+// fingerprintName returns the name of the constant holding the fingerprint of the named type,
+// exported like the type.
+func fingerprintName(name string) string { return name + "Fingerprint" }
+
+// CheckFingerprint returns an error if the layout of s, a struct or a *Layout, does not match
+// the fingerprint of the code generated from it. This happens when the struct is modified
+// without generating the code again, so tests can detect it with:
+//  func TestHeaderFingerprint(t *testing.T) {
+//    if err := packer.CheckFingerprint(Header{}, HeaderFingerprint); err != nil {
+//      t.Fatal(err)
+//    }
+//  }
+func CheckFingerprint(s interface{}, fingerprint string) error {
+	l, err := LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	if fp := l.Fingerprint(); fp != fingerprint {
+		return fmt.Errorf("packer: type %s: %w: fingerprint %s; generated from %s", l.Name, ErrStaleCode, fp, fingerprint)
+	}
+	return nil
+}
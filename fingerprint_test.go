@@ -0,0 +1,44 @@
+package packer
+
+import (
+	"bytes"
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestCheckFingerprint(t *testing.T) {
+	constant := regexp.MustCompile(`const Version2Fingerprint = "([0-9a-f]{16})"`)
+	var fingerprints []string
+	for _, config := range []*Config{{}, {MSBFirst: true, Reorder: ReorderSize}} {
+		buf := new(bytes.Buffer)
+		if err := GenPackedStruct(buf, config, Version2{}); err != nil {
+			t.Fatal(err)
+		}
+		m := constant.FindSubmatch(buf.Bytes())
+		if m == nil {
+			t.Fatalf("fingerprint not found in\n%s", buf)
+		}
+		fingerprints = append(fingerprints, string(m[1]))
+	}
+	// The fingerprint is the one of the struct, whatever the configuration.
+	fp := fingerprints[0]
+	if fingerprints[1] != fp {
+		t.Fatalf("got fingerprints %v; want the same", fingerprints)
+	}
+
+	if err := CheckFingerprint(Version2{}, fp); err != nil {
+		t.Fatal(err)
+	}
+	type Version2 struct {
+		version [4]uint
+		flag    bool
+		Len     [16]int `packer:"default=11"`
+	}
+	if err := CheckFingerprint(Version2{}, fp); !errors.Is(err, ErrStaleCode) {
+		t.Fatalf("got %v; want %v", err, ErrStaleCode)
+	}
+	if err := CheckFingerprint(0, fp); !errors.Is(err, ErrNotAStruct) {
+		t.Fatalf("got %v; want %v", err, ErrNotAStruct)
+	}
+}
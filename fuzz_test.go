@@ -0,0 +1,77 @@
+//go:build go1.18
+// +build go1.18
+
+package packer
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func FuzzParseBlock(f *testing.F) {
+	f.Add(EncodeBlock(nil, []uint64{1, 2, 3, 1 << 40}))
+	f.Add(EncodeBlock(nil, []uint64{0, 1 << 63}))
+	f.Fuzz(func(t *testing.T, in []byte) {
+		b, err := ParseBlock(in)
+		if err != nil {
+			return
+		}
+		values := b.AppendValues(nil)
+		for i, x := range values {
+			if got := b.At(i); got != x {
+				t.Fatalf("value %d: got %d; want %d", i, got, x)
+			}
+		}
+		b, err = ParseBlock(EncodeBlock(nil, values))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := b.AppendValues(nil); !reflect.DeepEqual(got, values) {
+			t.Fatalf("got %v; want %v", got, values)
+		}
+	})
+}
+
+func FuzzTimeSeriesDecoder(f *testing.F) {
+	buf := new(bytes.Buffer)
+	e := NewTimeSeriesEncoder(buf)
+	for i := int64(0); i < 10; i++ {
+		_ = e.Write(i*i, float64(i)/3)
+	}
+	_ = e.Close()
+	f.Add(buf.Bytes())
+	f.Fuzz(func(t *testing.T, in []byte) {
+		d := NewTimeSeriesDecoder(bytes.NewReader(in))
+		var points [][2]uint64
+		for d.Next() {
+			ts, v := d.At()
+			points = append(points, [2]uint64{uint64(ts), math.Float64bits(v)})
+		}
+		if d.Err() != nil {
+			return
+		}
+		// Valid series are encoded back to the same points.
+		buf := new(bytes.Buffer)
+		e := NewTimeSeriesEncoder(buf)
+		for _, p := range points {
+			if err := e.Write(int64(p[0]), math.Float64frombits(p[1])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := e.Close(); err != nil {
+			t.Fatal(err)
+		}
+		d = NewTimeSeriesDecoder(buf)
+		for i := 0; d.Next(); i++ {
+			ts, v := d.At()
+			if uint64(ts) != points[i][0] || math.Float64bits(v) != points[i][1] {
+				t.Fatalf("point %d: got %d, %v", i, ts, v)
+			}
+		}
+		if err := d.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package packer
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+)
+
+// Generator generates the code of several packed types in a single Go file,
+// with a single header and the imports they share.
+//
+// Example:
+//  g := packer.NewGenerator(&packer.Config{PkgName: "proto"})
+//  g.Add(Header{}, Trailer{})
+//  err := g.Generate(w)
+type Generator struct {
+	config *Config
+	types  []interface{}
+}
+
+// NewGenerator returns a Generator of the types packed with config.
+func NewGenerator(config *Config) *Generator {
+	return &Generator{config: config}
+}
+
+// Add adds the types defined by the structs or layouts s, as described by GenPackedStruct.
+func (g *Generator) Add(s ...interface{}) {
+	g.types = append(g.types, s...)
+}
+
+// Generate writes the code of the added types to w, formatted with gofmt.
+//
+// Nothing is written if any of the types is invalid, or if two of them have the same name.
+// The package name of the configuration must be set.
+func (g *Generator) Generate(w io.Writer) error {
+	config := g.config
+	config.init()
+	if config.PkgName == "" {
+		return fmt.Errorf("packer: generator: %w", ErrPkgName)
+	}
+
+	var imports []string
+	body := new(bytes.Buffer)
+	names := map[string]bool{}
+	for _, s := range g.types {
+		l, err := LayoutOf(s)
+		if err != nil {
+			return err
+		}
+		if names[l.Name] {
+			return fmt.Errorf("packer: type %s: %w", l.Name, ErrTypeRedeclared)
+		}
+		names[l.Name] = true
+
+		imp, code, err := genPackedLayout(config, l)
+		if err != nil {
+			return err
+		}
+		imports = append(imports, imp...)
+		body.Write(code)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("packer: generator: %w", ErrNoType)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := genHeader(buf, config, imports); err != nil {
+		return fmt.Errorf("packer: generator: %w", err)
+	}
+	buf.Write(body.Bytes())
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("packer: generator: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
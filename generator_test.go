@@ -0,0 +1,87 @@
+package packer
+
+import (
+	"bytes"
+	"errors"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerator(t *testing.T) {
+	type Date struct {
+		Month [4]time.Month
+		Day   [5]uint8
+	}
+	schedule := &Layout{Name: "Schedule", Size: 8, Fields: []Field{
+		{Name: "Weekday", Type: "time.Weekday", PkgPath: "time", Kind: reflect.Int, Bits: 3,
+			Enum: []EnumValue{{"First", 1}}},
+	}}
+
+	g := NewGenerator(&Config{TopComments: "// Generated.\n", PkgName: "testpkg", SQL: true})
+	g.Add(Version1{}, Date{})
+	g.Add(schedule)
+	buf := new(bytes.Buffer)
+	if err := g.Generate(buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	f, err := parser.ParseFile(token.NewFileSet(), "gen.go", out, parser.ImportsOnly)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var imports []string
+	for _, imp := range f.Imports {
+		imports = append(imports, imp.Path.Value)
+	}
+	if got, want := strings.Join(imports, " "), `"database/sql/driver" "fmt" "strconv" "time"`; got != want {
+		t.Fatalf("got imports %s; want %s", got, want)
+	}
+	for _, want := range []string{
+		"func (x Version1) version() uint",
+		"func (x Date) Month() time.Month",
+		"\tScheduleWeekdayFirst time.Weekday = 1\n",
+		"func (x *Schedule) Scan(src interface{}) error {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("%q not found in\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "package testpkg"); n != 1 {
+		t.Fatalf("got %d package clauses; want 1", n)
+	}
+}
+
+func TestGeneratorError(t *testing.T) {
+	type Broken struct {
+		Data float32
+	}
+	for _, tc := range []struct {
+		label string
+		pkg   string
+		types []interface{}
+		err   error
+	}{
+		{"invalid", "testpkg", []interface{}{Version1{}, Broken{}}, ErrFieldBadType},
+		{"redeclared", "testpkg", []interface{}{Version1{}, &Layout{Name: "Version1", Size: 8, Fields: []Field{{Name: "a", Type: "bool", Kind: reflect.Bool, Bits: 1}}}}, ErrTypeRedeclared},
+		{"empty", "testpkg", nil, ErrNoType},
+		{"no package", "", []interface{}{Version1{}}, ErrPkgName},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			g := NewGenerator(&Config{PkgName: tc.pkg})
+			g.Add(tc.types...)
+			buf := new(bytes.Buffer)
+			err := g.Generate(buf)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got %v; want %v", err, tc.err)
+			}
+			if buf.Len() > 0 {
+				t.Fatalf("got output on error:\n%s", buf)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package packer
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// goldenConfig is used to generate files that do not depend on the test command line.
+func goldenConfig() *Config {
+	return &Config{TopComments: "// Code generated by packer. DO NOT EDIT.\n"}
+}
+
+// checkGolden compares got with the content of testdata/name, or updates it if the -update flag is set.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s mismatch (run with -update if expected):\n%s", path, got)
+	}
+}
+
+// checkGenerated compares the Go source got with the file at path, both formatted,
+// or updates it if the -update flag is set.
+func checkGenerated(t *testing.T, path string, got []byte) {
+	t.Helper()
+	src, err := format.Source(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := ioutil.WriteFile(path, src, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, err = format.Source(want); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, want) {
+		t.Fatalf("%s mismatch (run with -update if expected):\n%s", path, src)
+	}
+}
+
+// langCase is a check of the code generated in another language: its code prints a line
+// that must be equal to want, as computed by the Go generated code.
+type langCase struct {
+	label, code, want string
+}
+
+// Packed values of the Ints and Version3 types used by langCases.
+var (
+	langInts uint64 = 0xFD | 0xFC18<<8 | 0xFFFE7960<<24
+	langV3   uint64 = 9 | 1<<4 | 1000<<12 | 0xDEADBEEF<<32
+)
+
+// langCases are checked in every generated language, in order, their code being given by label:
+//  ints      Ints set to -3, -1000 and -100000: value and fields
+//  version3  Version3 set to 9, true, 1000 and 0xDEADBEEF: value and fields
+//  reserved  same Version3 with its flag reset: flag and reserved bits
+var langCases = []langCase{
+	{label: "ints", want: fmt.Sprintf("%x %d %d %d", langInts, -3, -1000, -100000)},
+	{label: "version3", want: fmt.Sprintf("%x %d %d %d %x", langV3, 9, 1, 1000, 0xDEADBEEF)},
+	{label: "reserved", want: "0 0"},
+}
+
+// checkLang runs the program made of the code of langCases, as given by label, followed by
+// the one of extra and compares its output lines with the expected ones.
+// run builds the program from the code of the cases and returns its output.
+func checkLang(t *testing.T, code map[string]string, extra []langCase, run func(body string) []byte) {
+	t.Helper()
+	cases := append(append([]langCase(nil), langCases...), extra...)
+	body := make([]string, len(cases))
+	for i, c := range cases {
+		if c.code == "" {
+			c.code = code[c.label]
+		}
+		if c.code == "" {
+			t.Fatalf("%s: no code", c.label)
+		}
+		body[i] = c.code
+	}
+	got := strings.Split(strings.TrimSuffix(string(run(strings.Join(body, "\n"))), "\n"), "\n")
+	if len(got) != len(cases) {
+		t.Fatalf("got %d lines; want %d:\n%s", len(got), len(cases), strings.Join(got, "\n"))
+	}
+	for i, c := range cases {
+		if got[i] != c.want {
+			t.Errorf("%s: got %q; want %q", c.label, got[i], c.want)
+		}
+	}
+}
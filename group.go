@@ -0,0 +1,56 @@
+package packer
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pierrec/packer/internal/packuint"
+	"github.com/pierrec/packer/iobyte"
+)
+
+// PackGroupUint32 appends the values of src to dst using the group format and returns the extended buffer.
+//
+// The group format stores the lengths of 4 values in a control byte and
+// all the control bytes before the values themselves.
+// It is faster to decode than the per-value format for large arrays
+// but does not record the number of values.
+func PackGroupUint32(dst []byte, src []uint32) []byte {
+	return packuint.PackGroupUint32(dst, src)
+}
+
+// PackedSizeGroupUint32 returns the number of bytes used by PackGroupUint32 to pack src.
+func PackedSizeGroupUint32(src []uint32) int {
+	return packuint.PackedSizeGroupUint32(src)
+}
+
+// UnpackGroupUint32 appends the n values packed in src by PackGroupUint32 to dst
+// and returns the extended slice along with the number of bytes read.
+//
+// It returns an error wrapping ErrShortBuffer if src does not hold the n values
+// or ErrInvalidValue if n is negative.
+func UnpackGroupUint32(dst []uint32, src []byte, n int) ([]uint32, int, error) {
+	if n < 0 {
+		return dst, 0, fmt.Errorf("packer: group of %d uint32: %w", n, ErrInvalidValue)
+	}
+	dst, m, err := packuint.UnpackGroupUint32(dst, src, n)
+	if err != nil {
+		return dst, 0, fmt.Errorf("packer: group of %d uint32: %w", n, ErrShortBuffer)
+	}
+	return dst, m, nil
+}
+
+// PackGroupUint32To writes the number of values of src followed by their group packed values to w.
+//
+// buf is used as scratch space if it is large enough.
+func PackGroupUint32To(w io.Writer, buf []byte, src []uint32) error {
+	return packuint.PackGroupUint32To(w, buf, src)
+}
+
+// UnpackGroupUint32From reads values written by PackGroupUint32To from r and appends them to dst.
+//
+// r is read up to the last value only, so that successive groups can be read from it.
+// A reader that is not an io.ByteReader can be wrapped once in a bufio.Reader.
+// buf is used as scratch space if it is large enough.
+func UnpackGroupUint32From(r iobyte.ByteReader, buf []byte, dst []uint32) ([]uint32, error) {
+	return packuint.UnpackGroupUint32From(r, buf, dst)
+}
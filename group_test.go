@@ -0,0 +1,70 @@
+package packer
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestPackGroupUint32(t *testing.T) {
+	values := []uint32{0, 1, 1 << 10, 0xF0F0F0, 1 << 31, 7}
+	buf := PackGroupUint32(nil, values)
+	if got, want := len(buf), PackedSizeGroupUint32(values); got != want {
+		t.Fatalf("got %d bytes; want %d", got, want)
+	}
+	got, n, err := UnpackGroupUint32(nil, buf, len(values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(buf) || !reflect.DeepEqual(got, values) {
+		t.Fatalf("got %v in %d bytes; want %v in %d", got, n, values, len(buf))
+	}
+	if _, _, err := UnpackGroupUint32(nil, buf[:len(buf)-1], len(values)); !errors.Is(err, ErrShortBuffer) {
+		t.Fatalf("got %v; want %v", err, ErrShortBuffer)
+	}
+
+	rw := new(bytes.Buffer)
+	if err := PackGroupUint32To(rw, nil, values); err != nil {
+		t.Fatal(err)
+	}
+	got, err = UnpackGroupUint32From(rw, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, values) {
+		t.Fatalf("got %v; want %v", got, values)
+	}
+
+	if _, _, err := UnpackGroupUint32(nil, buf, -1); !errors.Is(err, ErrInvalidValue) {
+		t.Fatalf("got %v; want %v", err, ErrInvalidValue)
+	}
+}
+
+// readOnly hides the io.ByteReader implementation of its Reader.
+type readOnly struct{ io.Reader }
+
+func TestUnpackGroupUint32FromReader(t *testing.T) {
+	groups := [][]uint32{{1, 2, 3}, {4, 1 << 20}, nil}
+	w := new(bytes.Buffer)
+	for _, g := range groups {
+		if err := PackGroupUint32To(w, nil, g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := bufio.NewReader(readOnly{w})
+	for _, want := range groups {
+		got, err := UnpackGroupUint32From(r, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) || len(got) > 0 && !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v; want %v", got, want)
+		}
+	}
+	if _, err := UnpackGroupUint32From(r, nil, nil); err != io.EOF {
+		t.Fatalf("got %v; want %v", err, io.EOF)
+	}
+}
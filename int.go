@@ -0,0 +1,80 @@
+package packer
+
+import (
+	"io"
+
+	"github.com/pierrec/packer/internal/packuint"
+	"github.com/pierrec/packer/iobyte"
+)
+
+// PackInt64 packs x into buf and returns the number of bytes used.
+// x is zigzag encoded so that values of small magnitude use few bytes whatever their sign.
+//
+// buf is used as scratch space if it has at least 9 bytes in capacity.
+func PackInt64(buf []byte, x int64) int {
+	return packuint.PackInt64(minBuf(buf, 9), x)
+}
+
+// AppendInt64 appends the packed x to dst and returns the extended buffer.
+func AppendInt64(dst []byte, x int64) []byte {
+	return packuint.AppendInt64(dst, x)
+}
+
+// PackedSizeInt64 returns the number of bytes used to pack x.
+func PackedSizeInt64(x int64) int {
+	return packuint.PackedSizeInt64(x)
+}
+
+// PackInt64To packs x to w.
+//
+// buf is used as scratch space if it has at least 9 bytes in capacity.
+func PackInt64To(w io.Writer, buf []byte, x int64) error {
+	return packuint.PackInt64To(w, minBuf(buf, 9), x)
+}
+
+// UnpackInt64 unpacks buf and returns the value.
+func UnpackInt64(buf []byte) int64 {
+	return packuint.UnpackInt64(buf[0], buf[1:])
+}
+
+// UnpackInt64From unpacks an int64 from r.
+// Use a Decoder to read several values from the same reader.
+func UnpackInt64From(r io.Reader, buf []byte) (int64, error) {
+	return packuint.UnpackInt64From(iobyte.NewReader(r), minBuf(buf, 8))
+}
+
+// PackInt32 packs x into buf and returns the number of bytes used.
+// x is zigzag encoded so that values of small magnitude use few bytes whatever their sign.
+//
+// buf is used as scratch space if it has at least 5 bytes in capacity.
+func PackInt32(buf []byte, x int32) int {
+	return packuint.PackInt32(minBuf(buf, 5), x)
+}
+
+// AppendInt32 appends the packed x to dst and returns the extended buffer.
+func AppendInt32(dst []byte, x int32) []byte {
+	return packuint.AppendInt32(dst, x)
+}
+
+// PackedSizeInt32 returns the number of bytes used to pack x.
+func PackedSizeInt32(x int32) int {
+	return packuint.PackedSizeInt32(x)
+}
+
+// PackInt32To packs x to w.
+//
+// buf is used as scratch space if it has at least 5 bytes in capacity.
+func PackInt32To(w io.Writer, buf []byte, x int32) error {
+	return packuint.PackInt32To(w, minBuf(buf, 5), x)
+}
+
+// UnpackInt32 unpacks buf and returns the value.
+func UnpackInt32(buf []byte) int32 {
+	return packuint.UnpackInt32(buf[0], buf[1:])
+}
+
+// UnpackInt32From unpacks an int32 from r.
+// Use a Decoder to read several values from the same reader.
+func UnpackInt32From(r io.Reader, buf []byte) (int32, error) {
+	return packuint.UnpackInt32From(iobyte.NewReader(r), minBuf(buf, 4))
+}
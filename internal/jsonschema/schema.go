@@ -0,0 +1,226 @@
+// Package jsonschema validates YAML or JSON documents against the subset of JSON Schema
+// (draft 7) used by the layout schema: type, enum, properties, required,
+// additionalProperties, propertyNames, items, minItems, minimum, maximum,
+// pattern and references to its definitions.
+//
+// Other keywords are rejected so that a schema never validates less than it claims.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pierrec/packer/internal/yamlite"
+)
+
+// Schema is a compiled JSON Schema.
+type Schema struct {
+	Schema      string             `json:"$schema"`
+	ID          string             `json:"$id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Type        types              `json:"type"`
+	Enum        []interface{}      `json:"enum"` // strings or json.Number
+	Properties  map[string]*Schema `json:"properties"`
+	Required    []string           `json:"required"`
+	Additional  *Schema            `json:"additionalProperties"`
+	Names       *Schema            `json:"propertyNames"`
+	Items       *Schema            `json:"items"`
+	MinItems    int                `json:"minItems"`
+	Minimum     *int64             `json:"minimum"`
+	Maximum     *int64             `json:"maximum"`
+	Pattern     string             `json:"pattern"`
+	Ref         string             `json:"$ref"`
+	Definitions map[string]*Schema `json:"definitions"`
+
+	never   bool // the schema is false
+	pattern *regexp.Regexp
+}
+
+// types is the value of the type keyword, a string or an array of strings.
+type types []string
+
+func (t *types) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*t = types{s}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	if string(data) == "false" {
+		s.never = true
+		return nil
+	}
+	type schema Schema
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+	if err := dec.Decode((*schema)(s)); err != nil {
+		return err
+	}
+	if s.Ref != "" && !strings.HasPrefix(s.Ref, "#/definitions/") {
+		return fmt.Errorf("unsupported reference %q", s.Ref)
+	}
+	var err error
+	if s.Pattern != "" {
+		s.pattern, err = regexp.Compile(s.Pattern)
+	}
+	return err
+}
+
+// Error is returned when a document does not validate.
+type Error struct {
+	Path      string // JSON pointer to the invalid value
+	Line, Col int    // position of the invalid value, starting at 1
+	Msg       string
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("%d:%d: %s: %s", e.Line, e.Col, e.Path, e.Msg) }
+
+// Compile compiles the JSON schema in data.
+func Compile(data []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := s.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("invalid schema: %v", err)
+	}
+	return s, nil
+}
+
+// MustCompile is like Compile but panics on error.
+func MustCompile(data string) *Schema {
+	s, err := Compile([]byte(data))
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Validate checks the document n against the schema and returns the first error found.
+func (s *Schema) Validate(n *yamlite.Node) error {
+	return s.validate(s, n, "")
+}
+
+func (s *Schema) validate(root *Schema, n *yamlite.Node, path string) error {
+	fail := func(n *yamlite.Node, path, format string, args ...interface{}) error {
+		if path == "" {
+			path = "/"
+		}
+		return &Error{Path: path, Line: n.Line, Col: n.Col, Msg: fmt.Sprintf(format, args...)}
+	}
+	if s.Ref != "" {
+		def, ok := root.Definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]
+		if !ok {
+			return fail(n, path, "undefined schema reference %q", s.Ref)
+		}
+		if err := def.validate(root, n, path); err != nil {
+			return err
+		}
+	}
+
+	typ := n.Type()
+	if len(s.Type) > 0 && !hasType(s.Type, typ) {
+		return fail(n, path, "got %s; want %s", typ, strings.Join(s.Type, " or "))
+	}
+	if s.Enum != nil && !inEnum(s.Enum, n) {
+		values := make([]string, len(s.Enum))
+		for i, e := range s.Enum {
+			values[i] = fmt.Sprint(e)
+		}
+		return fail(n, path, "%q is not one of %s", n.Value, strings.Join(values, ", "))
+	}
+
+	switch typ {
+	case "object":
+		for _, r := range s.Required {
+			if n.Get(r) == nil {
+				return fail(n, path, "missing property %q", r)
+			}
+		}
+		for i, k := range n.Keys {
+			p := path + "/" + escape(k.Value)
+			if s.Names != nil {
+				if err := s.Names.validate(root, k, p); err != nil {
+					return err
+				}
+			}
+			sub, ok := s.Properties[k.Value]
+			if !ok {
+				if sub = s.Additional; sub == nil {
+					continue
+				}
+				if sub.never {
+					return fail(k, p, "unknown property %q", k.Value)
+				}
+			}
+			if err := sub.validate(root, n.Values[i], p); err != nil {
+				return err
+			}
+		}
+	case "array":
+		if len(n.Items) < s.MinItems {
+			return fail(n, path, "got %d items; want at least %d", len(n.Items), s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range n.Items {
+				if err := s.Items.validate(root, item, fmt.Sprintf("%s/%d", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "integer":
+		x, err := n.Int()
+		if err != nil {
+			return fail(n, path, "%v", err)
+		}
+		if s.Minimum != nil && x < *s.Minimum {
+			return fail(n, path, "%s is less than %d", n.Value, *s.Minimum)
+		}
+		if s.Maximum != nil && x > *s.Maximum {
+			return fail(n, path, "%s is greater than %d", n.Value, *s.Maximum)
+		}
+	case "string":
+		if s.pattern != nil && !s.pattern.MatchString(n.Value) {
+			return fail(n, path, "%q does not match %s", n.Value, s.pattern)
+		}
+	}
+	return nil
+}
+
+// hasType reports whether typ is one of types.
+func hasType(types []string, typ string) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// inEnum reports whether the scalar n is one of the enum values.
+func inEnum(enum []interface{}, n *yamlite.Node) bool {
+	for _, e := range enum {
+		switch e := e.(type) {
+		case string:
+			if n.Type() == "string" && n.Value == e {
+				return true
+			}
+		case json.Number:
+			if x, err := n.Int(); err == nil && strconv.FormatInt(x, 10) == e.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// escape escapes a JSON pointer reference token.
+func escape(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
@@ -0,0 +1,91 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pierrec/packer/internal/yamlite"
+)
+
+const testSchema = `{
+  "title": "test",
+  "type": "object",
+  "required": ["name"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {"type": "string", "pattern": "^[a-z]+$"},
+    "size": {"type": "integer", "minimum": 1, "maximum": 8},
+    "kind": {"enum": ["a", "b", 1]},
+    "tags": {"type": "array", "minItems": 1, "items": {"$ref": "#/definitions/tag"}},
+    "map": {"type": "object", "propertyNames": {"pattern": "^[A-Z]"}, "additionalProperties": {"type": ["integer", "boolean"]}}
+  },
+  "definitions": {
+    "tag": {"type": "string", "description": "tag name"}
+  }
+}`
+
+func TestValidate(t *testing.T) {
+	s, err := Compile([]byte(testSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		label, in, err string
+	}{
+		{"valid", "name: x\nsize: 2\nkind: 1\ntags: [t, u]\nmap:\n  A: 1\n  B: true\n", ""},
+		{"number", "name: x\nsize: 2.5", "2:7: /size: got number; want integer"},
+		{"not an object", "- x", "1:1: /: got array; want object"},
+		{"required", "size: 1", `1:1: /: missing property "name"`},
+		{"additional", "name: x\nother: 1", `2:1: /other: unknown property "other"`},
+		{"pattern", "name: X", `1:7: /name: "X" does not match ^[a-z]+$`},
+		{"minimum", "name: x\nsize: 0", "2:7: /size: 0 is less than 1"},
+		{"maximum", "name: x\nsize: 9", "2:7: /size: 9 is greater than 8"},
+		{"enum", "name: x\nkind: '1'", `2:7: /kind: "1" is not one of a, b, 1`},
+		{"min items", "name: x\ntags: []", "2:7: /tags: got 0 items; want at least 1"},
+		{"reference", "name: x\ntags: [a, 2]", "2:11: /tags/1: got integer; want string"},
+		{"property names", "name: x\nmap:\n  a/b: 1", `3:3: /map/a~1b: "a/b" does not match ^[A-Z]`},
+		{"additional properties", "name: x\nmap:\n  A: x", "3:6: /map/A: got string; want integer or boolean"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			n, err := yamlite.Parse([]byte(tc.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = s.Validate(n)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatal(err)
+			case tc.err == "":
+			case err == nil:
+				t.Fatal("expected error")
+			case err.Error() != tc.err:
+				t.Fatalf("got %q; want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	// The messages of encoding/json vary with the Go version: only their salient part is checked.
+	for _, tc := range []struct {
+		label, in, err string
+	}{
+		{"syntax", `{"type": x}`, "invalid character 'x'"},
+		{"not an object", `[]`, "cannot unmarshal array"},
+		{"keyword", `{"oneOf": []}`, `unknown field "oneOf"`},
+		{"nested keyword", `{"items": {"maxItems": 1}}`, `unknown field "maxItems"`},
+		{"reference", `{"$ref": "other.json"}`, `unsupported reference "other.json"`},
+		{"pattern", `{"pattern": "("}`, "error parsing regexp: missing closing ): `(`"},
+		{"minimum", `{"minimum": 1.5}`, "cannot unmarshal number 1.5"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			_, err := Compile([]byte(tc.in))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got := err.Error(); !strings.HasPrefix(got, "invalid schema: ") || !strings.Contains(got, tc.err) {
+				t.Fatalf("got %q; want invalid schema: ...%s...", got, tc.err)
+			}
+		})
+	}
+}
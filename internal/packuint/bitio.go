@@ -0,0 +1,83 @@
+package packuint
+
+import "github.com/pierrec/packer/iobyte"
+
+// BitWriter writes bits to a ByteWriter, most significant first.
+type BitWriter struct {
+	w   iobyte.ByteWriter
+	acc byte
+	n   int // number of bits in acc
+}
+
+// NewBitWriter returns a BitWriter writing to w.
+func NewBitWriter(w iobyte.ByteWriter) *BitWriter {
+	return &BitWriter{w: w}
+}
+
+// WriteBits writes the n lowest bits of x.
+func (w *BitWriter) WriteBits(x uint64, n int) error {
+	for n > 0 {
+		free := 8 - w.n
+		m := n
+		if m > free {
+			m = free
+		}
+		b := byte(x>>(n-m)) & (1<<m - 1)
+		w.acc |= b << (free - m)
+		w.n += m
+		n -= m
+		if w.n == 8 {
+			if err := w.w.WriteByte(w.acc); err != nil {
+				return err
+			}
+			w.acc, w.n = 0, 0
+		}
+	}
+	return nil
+}
+
+// Flush writes the pending bits, padded with zeros up to the next byte boundary.
+func (w *BitWriter) Flush() error {
+	if w.n == 0 {
+		return nil
+	}
+	err := w.w.WriteByte(w.acc)
+	w.acc, w.n = 0, 0
+	return err
+}
+
+// BitReader reads bits from a ByteReader, most significant first.
+type BitReader struct {
+	r   iobyte.ByteReader
+	acc byte
+	n   int // number of unread bits in acc
+}
+
+// NewBitReader returns a BitReader reading from r.
+func NewBitReader(r iobyte.ByteReader) *BitReader {
+	return &BitReader{r: r}
+}
+
+// ReadBits reads n bits and returns them as the lowest bits of x.
+func (r *BitReader) ReadBits(n int) (x uint64, err error) {
+	for n > 0 {
+		if r.n == 0 {
+			if r.acc, err = r.r.ReadByte(); err != nil {
+				return
+			}
+			r.n = 8
+		}
+		m := n
+		if m > r.n {
+			m = r.n
+		}
+		b := r.acc >> (r.n - m) & (1<<m - 1)
+		x = x<<m | uint64(b)
+		r.n -= m
+		n -= m
+	}
+	return
+}
+
+// Align discards the unread bits of the current byte.
+func (r *BitReader) Align() { r.n = 0 }
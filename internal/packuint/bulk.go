@@ -0,0 +1,102 @@
+package packuint
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// grow returns buf with room for at least n more bytes.
+func grow(buf []byte, n int) []byte {
+	if cap(buf)-len(buf) >= n {
+		return buf
+	}
+	b := make([]byte, len(buf), 2*cap(buf)+n)
+	copy(b, buf)
+	return b
+}
+
+// PackUint64s appends the packed values of src to dst and returns the extended buffer.
+func PackUint64s(dst []byte, src []uint64) []byte {
+	dst = grow(dst, 9*len(src))
+	n := len(dst)
+	buf := dst[:cap(dst)]
+	for _, x := range src {
+		n += PackUint64(buf[n:], x)
+	}
+	return dst[:n]
+}
+
+// UnpackUint64s appends the values packed in src to dst and returns the extended slice
+// along with the number of bytes read, which is less than len(src) if the last value is truncated.
+func UnpackUint64s(dst []uint64, src []byte) ([]uint64, int) {
+	var i int
+	// Load all the bytes a value can have at once and scatter the ones flagged in the bitmap,
+	// which is faster than looking up the byte positions in unpackTable.
+	for i+9 <= len(src) {
+		bitmap := src[i]
+		v := binary.LittleEndian.Uint64(src[i+1:])
+		var x uint64
+		if bitmap == 0xFF {
+			x = v
+		} else {
+			// Bit 7 of the bitmap is for the least significant byte.
+			for r := bits.Reverse8(bitmap); r != 0; r &= r - 1 {
+				x |= v & 0xFF << (uint(bits.TrailingZeros8(r)) * 8)
+				v >>= 8
+			}
+		}
+		dst = append(dst, x)
+		i += PackedLenUint64(bitmap)
+	}
+	for i < len(src) {
+		n := PackedLenUint64(src[i])
+		if i+n > len(src) {
+			break
+		}
+		dst = append(dst, UnpackUint64(src[i], src[i+1:i+n]))
+		i += n
+	}
+	return dst, i
+}
+
+// PackUint32s appends the packed values of src to dst and returns the extended buffer.
+func PackUint32s(dst []byte, src []uint32) []byte {
+	dst = grow(dst, 5*len(src))
+	n := len(dst)
+	buf := dst[:cap(dst)]
+	for _, x := range src {
+		n += PackUint32(buf[n:], x)
+	}
+	return dst[:n]
+}
+
+// UnpackUint32s appends the values packed in src to dst and returns the extended slice
+// along with the number of bytes read, which is less than len(src) if the last value is truncated.
+func UnpackUint32s(dst []uint32, src []byte) ([]uint32, int) {
+	var i int
+	for i+5 <= len(src) {
+		bitmap := src[i]
+		v := binary.LittleEndian.Uint32(src[i+1:])
+		var x uint32
+		if bitmap == 0xFF {
+			x = v
+		} else {
+			// Bit 7 of the bitmap is for the least significant nibble.
+			for r := bits.Reverse8(bitmap); r != 0; r &= r - 1 {
+				x |= v & 0xF << (uint(bits.TrailingZeros8(r)) * 4)
+				v >>= 4
+			}
+		}
+		dst = append(dst, x)
+		i += PackedLenUint32(bitmap)
+	}
+	for i < len(src) {
+		n := PackedLenUint32(src[i])
+		if i+n > len(src) {
+			break
+		}
+		dst = append(dst, UnpackUint32(src[i], src[i+1:i+n]))
+		i += n
+	}
+	return dst, i
+}
@@ -0,0 +1,154 @@
+package packuint
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// bulkValues returns n values of random magnitudes.
+func bulkValues(n int) []uint64 {
+	r := rand.New(rand.NewSource(1))
+	values := make([]uint64, n)
+	for i := range values {
+		values[i] = r.Uint64() >> r.Intn(65)
+	}
+	return values
+}
+
+func TestUnpackUint64s(t *testing.T) {
+	values := bulkValues(1000)
+	buf := PackUint64s([]byte{1}, values)
+	var want []byte
+	for _, x := range values {
+		want = AppendUint64(want, x)
+	}
+	if !reflect.DeepEqual(buf[1:], want) {
+		t.Fatal("PackUint64s and AppendUint64 differ")
+	}
+
+	got, n := UnpackUint64s([]uint64{1}, buf[1:])
+	if n != len(buf)-1 {
+		t.Fatalf("got %d bytes; want %d", n, len(buf)-1)
+	}
+	if !reflect.DeepEqual(got[1:], values) {
+		t.Fatal("values differ")
+	}
+
+	// Truncated input.
+	last := PackedSizeUint64(values[len(values)-1])
+	got, n = UnpackUint64s(nil, buf[1:len(buf)-1])
+	if n != len(buf)-1-last || len(got) != len(values)-1 {
+		t.Fatalf("got %d values in %d bytes; want %d in %d", len(got), n, len(values)-1, len(buf)-1-last)
+	}
+}
+
+func TestUnpackUint32s(t *testing.T) {
+	var values []uint32
+	for _, x := range bulkValues(1000) {
+		values = append(values, uint32(x))
+	}
+	buf := PackUint32s([]byte{1}, values)
+	var want []byte
+	for _, x := range values {
+		want = AppendUint32(want, x)
+	}
+	if !reflect.DeepEqual(buf[1:], want) {
+		t.Fatal("PackUint32s and AppendUint32 differ")
+	}
+
+	got, n := UnpackUint32s([]uint32{1}, buf[1:])
+	if n != len(buf)-1 {
+		t.Fatalf("got %d bytes; want %d", n, len(buf)-1)
+	}
+	if !reflect.DeepEqual(got[1:], values) {
+		t.Fatal("values differ")
+	}
+
+	// Truncated input.
+	last := PackedSizeUint32(values[len(values)-1])
+	got, n = UnpackUint32s(nil, buf[1:len(buf)-1])
+	if n != len(buf)-1-last || len(got) != len(values)-1 {
+		t.Fatalf("got %d values in %d bytes; want %d in %d", len(got), n, len(values)-1, len(buf)-1-last)
+	}
+}
+
+var benchBuf []byte
+var benchValues []uint64
+
+func BenchmarkPackUint64s(b *testing.B) {
+	values := bulkValues(1024)
+	buf := make([]byte, 0, 9*len(values))
+	b.SetBytes(int64(8 * len(values)))
+	for i := 0; i < b.N; i++ {
+		benchBuf = PackUint64s(buf, values)
+	}
+}
+
+func BenchmarkPackUint64sPerValue(b *testing.B) {
+	values := bulkValues(1024)
+	buf := make([]byte, 9*len(values))
+	b.SetBytes(int64(8 * len(values)))
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for _, x := range values {
+			n += PackUint64(buf[n:], x)
+		}
+		benchInt = n
+	}
+}
+
+func BenchmarkPackUint64sUvarint(b *testing.B) {
+	values := bulkValues(1024)
+	buf := make([]byte, binary.MaxVarintLen64*len(values))
+	b.SetBytes(int64(8 * len(values)))
+	for i := 0; i < b.N; i++ {
+		n := 0
+		for _, x := range values {
+			n += binary.PutUvarint(buf[n:], x)
+		}
+		benchInt = n
+	}
+}
+
+func BenchmarkUnpackUint64s(b *testing.B) {
+	values := bulkValues(1024)
+	buf := PackUint64s(nil, values)
+	b.SetBytes(int64(8 * len(values)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchValues, benchInt = UnpackUint64s(values[:0], buf)
+	}
+}
+
+func BenchmarkUnpackUint64sPerValue(b *testing.B) {
+	values := bulkValues(1024)
+	buf := PackUint64s(nil, values)
+	b.SetBytes(int64(8 * len(values)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, n := 0, 0; n < len(buf); j++ {
+			m := PackedLenUint64(buf[n])
+			values[j] = UnpackUint64(buf[n], buf[n+1:n+m])
+			n += m
+		}
+	}
+}
+
+func BenchmarkUnpackUint64sUvarint(b *testing.B) {
+	values := bulkValues(1024)
+	buf := make([]byte, 0, binary.MaxVarintLen64*len(values))
+	for _, x := range values {
+		buf = buf[:len(buf)+binary.PutUvarint(buf[len(buf):cap(buf)], x)]
+	}
+	b.SetBytes(int64(8 * len(values)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j, n := 0, 0; n < len(buf); j++ {
+			x, m := binary.Uvarint(buf[n:])
+			values[j] = x
+			n += m
+		}
+	}
+}
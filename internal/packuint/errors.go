@@ -0,0 +1,12 @@
+package packuint
+
+import "io"
+
+// UnexpectedEOF returns io.ErrUnexpectedEOF if err is io.EOF, err otherwise.
+// It is used when the end of the input is reached in the middle of a value.
+func UnexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
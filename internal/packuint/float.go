@@ -0,0 +1,104 @@
+package packuint
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Floats are encoded as in Facebook's Gorilla, by XORing each value with the previous one:
+//  - 0: same value
+//  - 10: the meaningful bits fit the window of the previous value, followed by them
+//  - 11: new window, followed by its number of leading zeros on 5 bits,
+//    its number of meaningful bits on 6 bits (0 for 64) and the meaningful bits
+// The previous value of the first one is 0 and its window covers the 64 bits.
+// A new window is also used when it is smaller than the previous one.
+
+// FloatEncoder encodes a series of float64 values.
+type FloatEncoder struct {
+	w                 *BitWriter
+	prev              uint64
+	leading, trailing int
+}
+
+// NewFloatEncoder returns a FloatEncoder writing to w.
+func NewFloatEncoder(w *BitWriter) *FloatEncoder {
+	return &FloatEncoder{w: w}
+}
+
+// Encode writes v.
+func (e *FloatEncoder) Encode(v float64) error {
+	x := math.Float64bits(v)
+	xor := x ^ e.prev
+	e.prev = x
+	if xor == 0 {
+		return e.w.WriteBits(0, 1)
+	}
+	leading, trailing := bits.LeadingZeros64(xor), bits.TrailingZeros64(xor)
+	if leading > 31 {
+		// Only 5 bits available.
+		leading = 31
+	}
+	n := 64 - leading - trailing
+	// Reuse the previous window if it holds the meaningful bits and is not larger
+	// than a new one, including the 11 bits describing it.
+	if leading >= e.leading && trailing >= e.trailing && 64-e.leading-e.trailing <= n+11 {
+		if err := e.w.WriteBits(0b10, 2); err != nil {
+			return err
+		}
+		return e.w.WriteBits(xor>>e.trailing, 64-e.leading-e.trailing)
+	}
+	e.leading, e.trailing = leading, trailing
+	// Control bits, leading zeros and number of meaningful bits.
+	if err := e.w.WriteBits(0b11<<11|uint64(leading)<<6|uint64(n&63), 13); err != nil {
+		return err
+	}
+	return e.w.WriteBits(xor>>trailing, n)
+}
+
+// FloatDecoder decodes a series of float64 values written by a FloatEncoder.
+type FloatDecoder struct {
+	r                 *BitReader
+	prev              uint64
+	leading, trailing int
+}
+
+// NewFloatDecoder returns a FloatDecoder reading from r.
+func NewFloatDecoder(r *BitReader) *FloatDecoder {
+	return &FloatDecoder{r: r}
+}
+
+// Decode reads the next value.
+func (d *FloatDecoder) Decode() (float64, error) {
+	ctrl, err := d.r.ReadBits(1)
+	if err != nil {
+		return 0, err
+	}
+	if ctrl == 0 {
+		return math.Float64frombits(d.prev), nil
+	}
+	if ctrl, err = d.r.ReadBits(1); err != nil {
+		return 0, err
+	}
+	if ctrl == 1 {
+		w, err := d.r.ReadBits(11)
+		if err != nil {
+			return 0, err
+		}
+		n := int(w & 63)
+		if n == 0 {
+			n = 64
+		}
+		d.leading = int(w >> 6)
+		d.trailing = 64 - d.leading - n
+		if d.trailing < 0 {
+			// Corrupt input: keep the window within 64 bits.
+			d.trailing = 0
+		}
+	}
+	xor, err := d.r.ReadBits(64 - d.leading - d.trailing)
+	if err != nil {
+		return 0, err
+	}
+	d.prev ^= xor << d.trailing
+	return math.Float64frombits(d.prev), nil
+}
@@ -0,0 +1,67 @@
+package packuint
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestBits(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewBitWriter(buf)
+	for n := 0; n <= 64; n++ {
+		if err := w.WriteBits(math.MaxUint64-uint64(n), n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// 0+1+...+64 bits.
+	if got, want := buf.Len(), (64*65/2+7)/8; got != want {
+		t.Fatalf("got %d bytes; want %d", got, want)
+	}
+	r := NewBitReader(buf)
+	for n := 0; n <= 64; n++ {
+		x, err := r.ReadBits(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := math.MaxUint64 - uint64(n)
+		if n < 64 {
+			want &= 1<<n - 1
+		}
+		if x != want {
+			t.Fatalf("%d bits: got %#x; want %#x", n, x, want)
+		}
+	}
+}
+
+func TestFloat(t *testing.T) {
+	values := []float64{
+		0, 0, 1, 1.5, 1.25, -1, 1e300, math.Pi, math.Pi, math.E,
+		math.Inf(1), math.Inf(-1), math.NaN(), math.Copysign(0, -1),
+		math.SmallestNonzeroFloat64, math.MaxFloat64, 20, 20.5, 21, 21.5,
+	}
+	buf := new(bytes.Buffer)
+	w := NewBitWriter(buf)
+	e := NewFloatEncoder(w)
+	for _, v := range values {
+		if err := e.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	d := NewFloatDecoder(NewBitReader(buf))
+	for i, want := range values {
+		got, err := d.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Float64bits(got) != math.Float64bits(want) {
+			t.Fatalf("value %d: got %v; want %v", i, got, want)
+		}
+	}
+}
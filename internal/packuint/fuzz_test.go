@@ -0,0 +1,205 @@
+//go:build go1.18
+// +build go1.18
+
+package packuint
+
+import (
+	"bytes"
+	"math"
+	"math/bits"
+	"reflect"
+	"testing"
+)
+
+func FuzzPackInt64(f *testing.F) {
+	for _, x := range []int64{0, -1, 1, -3200, 1 << 62, -1 << 63} {
+		f.Add(x)
+	}
+	f.Fuzz(func(t *testing.T, x int64) {
+		buf := make([]byte, 9)
+		n := PackInt64(buf, x)
+		if got := PackedSizeInt64(x); got != n {
+			t.Fatalf("got size %d; want %d", got, n)
+		}
+		if got := UnpackInt64(buf[0], buf[1:n]); got != x {
+			t.Fatalf("got %d; want %d", got, x)
+		}
+		if x >= -128 && x < 128 && n > 2 {
+			t.Fatalf("%d packed into %d bytes; want at most 2", x, n)
+		}
+		got, err := UnpackInt64From(bytes.NewReader(buf[:n]), make([]byte, 8))
+		if err != nil || got != x {
+			t.Fatalf("got %d, %v; want %d", got, err, x)
+		}
+	})
+}
+
+func FuzzPackInt32(f *testing.F) {
+	for _, x := range []int32{0, -1, 1, -3200, 1 << 30, -1 << 31} {
+		f.Add(x)
+	}
+	f.Fuzz(func(t *testing.T, x int32) {
+		buf := make([]byte, 5)
+		n := PackInt32(buf, x)
+		if got := PackedSizeInt32(x); got != n {
+			t.Fatalf("got size %d; want %d", got, n)
+		}
+		if got := UnpackInt32(buf[0], buf[1:n]); got != x {
+			t.Fatalf("got %d; want %d", got, x)
+		}
+		if x >= -128 && x < 128 && n > 2 {
+			t.Fatalf("%d packed into %d bytes; want at most 2", x, n)
+		}
+		got, err := UnpackInt32From(bytes.NewReader(buf[:n]), make([]byte, 4))
+		if err != nil || got != x {
+			t.Fatalf("got %d, %v; want %d", got, err, x)
+		}
+	})
+}
+
+func FuzzUnpackUint64Strict(f *testing.F) {
+	for _, b := range [][]byte{{0}, {0x80, 1}, {0x80, 0}, {0xFF, 1, 2, 3, 4, 5, 6, 7, 8}, {0x81, 1, 0}} {
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, in []byte) {
+		if len(in) == 0 {
+			return
+		}
+		n := 1 + bits.OnesCount8(in[0])
+		if len(in) < n {
+			return
+		}
+		x, err := UnpackUint64Strict(in[0], in[1:n])
+		buf := make([]byte, 9)
+		m := PackUint64(buf, x)
+		if err == nil && !bytes.Equal(buf[:m], in[:n]) {
+			t.Fatalf("%x accepted but %d packs to %x", in[:n], x, buf[:m])
+		}
+		// Pack output is always accepted.
+		x = UnpackUint64(in[0], in[1:n])
+		m = PackUint64(buf, x)
+		if got, err := UnpackUint64Strict(buf[0], buf[1:m]); err != nil || got != x {
+			t.Fatalf("got %d, %v; want %d", got, err, x)
+		}
+	})
+}
+
+func FuzzUnpackUint32Strict(f *testing.F) {
+	for _, b := range [][]byte{{0}, {0x80, 1}, {0x80, 0x21}, {0xFF, 0x21, 0x43, 0x65, 0x87}, {0xC0, 0x01}} {
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, in []byte) {
+		if len(in) == 0 {
+			return
+		}
+		n := 1 + (bits.OnesCount8(in[0])+1)/2
+		if len(in) < n {
+			return
+		}
+		x, err := UnpackUint32Strict(in[0], in[1:n])
+		buf := make([]byte, 5)
+		m := PackUint32(buf, x)
+		if err == nil && !bytes.Equal(buf[:m], in[:n]) {
+			t.Fatalf("%x accepted but %d packs to %x", in[:n], x, buf[:m])
+		}
+		// Pack output is always accepted.
+		x = UnpackUint32(in[0], in[1:n])
+		m = PackUint32(buf, x)
+		if got, err := UnpackUint32Strict(buf[0], buf[1:m]); err != nil || got != x {
+			t.Fatalf("got %d, %v; want %d", got, err, x)
+		}
+	})
+}
+
+func FuzzUnpackUint64s(f *testing.F) {
+	f.Add(PackUint64s(nil, bulkValues(20)))
+	f.Fuzz(func(t *testing.T, in []byte) {
+		got, n := UnpackUint64s(nil, in)
+		var want []uint64
+		m := 0
+		for m < len(in) && m+PackedLenUint64(in[m]) <= len(in) {
+			k := PackedLenUint64(in[m])
+			want = append(want, UnpackUint64(in[m], in[m+1:m+k]))
+			m += k
+		}
+		if n != m || len(got) != len(want) {
+			t.Fatalf("got %d values in %d bytes; want %d in %d", len(got), n, len(want), m)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("value %d: got %d; want %d", i, got[i], want[i])
+			}
+		}
+	})
+}
+
+func FuzzUnpackUint32s(f *testing.F) {
+	f.Add(PackUint32s(nil, []uint32{0, 1, 0xF0F0F0F0, 3200, 1 << 31}))
+	f.Fuzz(func(t *testing.T, in []byte) {
+		got, n := UnpackUint32s(nil, in)
+		var want []uint32
+		m := 0
+		for m < len(in) && m+PackedLenUint32(in[m]) <= len(in) {
+			k := PackedLenUint32(in[m])
+			want = append(want, UnpackUint32(in[m], in[m+1:m+k]))
+			m += k
+		}
+		if n != m || len(got) != len(want) {
+			t.Fatalf("got %d values in %d bytes; want %d in %d", len(got), n, len(want), m)
+		}
+		for i := range got {
+			if got[i] != want[i] {
+				t.Fatalf("value %d: got %d; want %d", i, got[i], want[i])
+			}
+		}
+	})
+}
+
+func FuzzUnpackGroupUint32(f *testing.F) {
+	f.Add(PackGroupUint32(nil, []uint32{0, 1, 0xF0F0F0F0, 3200, 1 << 31}), 5)
+	f.Fuzz(func(t *testing.T, in []byte, n int) {
+		if n > 4*len(in) {
+			return
+		}
+		got, m, err := UnpackGroupUint32(nil, in, n)
+		if err != nil {
+			return
+		}
+		if len(got) != n || m > len(in) {
+			t.Fatalf("got %d values in %d bytes; want %d in at most %d", len(got), m, n, len(in))
+		}
+		buf := PackGroupUint32(nil, got)
+		values, _, err := UnpackGroupUint32(nil, buf, n)
+		if err != nil || !reflect.DeepEqual(values, got) {
+			t.Fatalf("got %v, %v; want %v", values, err, got)
+		}
+	})
+}
+
+func FuzzFloat(f *testing.F) {
+	f.Add(1.0, 1.5, 2.0)
+	f.Add(0.0, math.Inf(1), math.NaN())
+	f.Fuzz(func(t *testing.T, a, b, c float64) {
+		buf := new(bytes.Buffer)
+		w := NewBitWriter(buf)
+		e := NewFloatEncoder(w)
+		for _, v := range []float64{a, b, c, a} {
+			if err := e.Encode(v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		d := NewFloatDecoder(NewBitReader(buf))
+		for i, want := range []float64{a, b, c, a} {
+			got, err := d.Decode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Float64bits(got) != math.Float64bits(want) {
+				t.Fatalf("value %d: got %v; want %v", i, got, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package packuint
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+
+	"github.com/pierrec/packer/iobyte"
+)
+
+// Group encoding of uint32 values, in the style of Stream VByte:
+//  - control bytes, each holding the 2 bits length codes of 4 values, first value in the lowest bits
+//  - followed by the values, each one on as many little endian bytes as its length code plus 1
+// The control bytes being separated from the data, decoding needs a single table lookup per 4 values.
+
+// ErrGroupCount is returned when the number of group packed values is negative
+// or, when read from a stream, cannot be held in memory.
+var ErrGroupCount = errors.New("invalid group count")
+
+// groupChunk is the maximum number of bytes allocated ahead of the ones actually read
+// from a stream, so that a corrupt count does not trigger a huge allocation.
+const groupChunk = 64 << 10
+
+// groupEntry describes the data of the 4 values of a control byte.
+type groupEntry struct {
+	offset [4]uint8
+	mask   [4]uint32
+	size   uint8
+}
+
+var groupTable = func() (table [256]groupEntry) {
+	for c := range table {
+		e := &table[c]
+		for i := 0; i < 4; i++ {
+			n := c>>(2*i)&3 + 1
+			e.offset[i] = e.size
+			e.mask[i] = ^uint32(0) >> (32 - 8*n)
+			e.size += uint8(n)
+		}
+	}
+	return
+}()
+
+// groupCode returns the length code of x.
+func groupCode(x uint32) byte {
+	if x == 0 {
+		return 0
+	}
+	return byte(bits.Len32(x)-1) / 8
+}
+
+// GroupControlLen returns the number of control bytes for n values.
+func GroupControlLen(n int) int {
+	return (n + 3) / 4
+}
+
+// GroupDataLen returns the number of data bytes for the n values described by control,
+// which must hold GroupControlLen(n) bytes.
+func GroupDataLen(control []byte, n int) int {
+	if n == 0 {
+		return 0
+	}
+	size := 0
+	for _, c := range control[:len(control)-1] {
+		size += int(groupTable[c].size)
+	}
+	// The last control byte may describe less than 4 values.
+	c := control[len(control)-1]
+	for i := 0; i < n-4*(len(control)-1); i++ {
+		size += int(c>>(2*i)&3) + 1
+	}
+	return size
+}
+
+// PackedSizeGroupUint32 returns the number of bytes used to group pack src.
+func PackedSizeGroupUint32(src []uint32) int {
+	size := GroupControlLen(len(src))
+	for _, x := range src {
+		size += int(groupCode(x)) + 1
+	}
+	return size
+}
+
+// PackGroupUint32 appends the group packed values of src to dst and returns the extended buffer.
+func PackGroupUint32(dst []byte, src []uint32) []byte {
+	ctrl := len(dst)
+	dst = grow(dst, GroupControlLen(len(src))+4*len(src))
+	data := ctrl + GroupControlLen(len(src))
+	buf := dst[:cap(dst)]
+	for i, x := range src {
+		if i%4 == 0 {
+			buf[ctrl+i/4] = 0
+		}
+		code := groupCode(x)
+		buf[ctrl+i/4] |= code << (2 * (i % 4))
+		// Write all 4 bytes and only keep the significant ones.
+		binary.LittleEndian.PutUint32(buf[data:], x)
+		data += int(code) + 1
+	}
+	return dst[:data]
+}
+
+// UnpackGroupUint32 appends the n group packed values of src to dst and returns the extended slice
+// along with the number of bytes read.
+//
+// It returns io.ErrUnexpectedEOF if src does not hold the n values, or ErrGroupCount if n is negative.
+func UnpackGroupUint32(dst []uint32, src []byte, n int) ([]uint32, int, error) {
+	if n < 0 {
+		return dst, 0, ErrGroupCount
+	}
+	nctrl := GroupControlLen(n)
+	if len(src) < nctrl {
+		return dst, 0, io.ErrUnexpectedEOF
+	}
+	control, data := src[:nctrl], src[nctrl:]
+	size := GroupDataLen(control, n)
+	if len(data) < size {
+		return dst, 0, io.ErrUnexpectedEOF
+	}
+
+	// Full groups of 4 values, as long as 4 bytes can be loaded for each of them.
+	var i, pos int
+	for ; i+4 <= n && pos+16 <= len(data); i += 4 {
+		e := &groupTable[control[i/4]]
+		d := data[pos : pos+16]
+		dst = append(dst,
+			binary.LittleEndian.Uint32(d[e.offset[0]:])&e.mask[0],
+			binary.LittleEndian.Uint32(d[e.offset[1]:])&e.mask[1],
+			binary.LittleEndian.Uint32(d[e.offset[2]:])&e.mask[2],
+			binary.LittleEndian.Uint32(d[e.offset[3]:])&e.mask[3],
+		)
+		pos += int(e.size)
+	}
+	// Remaining values, byte by byte.
+	for ; i < n; i++ {
+		size := int(control[i/4]>>(2*(i%4))&3) + 1
+		var x uint32
+		for j := size - 1; j >= 0; j-- {
+			x = x<<8 | uint32(data[pos+j])
+		}
+		dst = append(dst, x)
+		pos += size
+	}
+	return dst, nctrl + pos, nil
+}
+
+// PackGroupUint32To writes the number of values of src followed by their group packed values to w.
+//
+// buf is used as scratch space if it is large enough.
+func PackGroupUint32To(w io.Writer, buf []byte, src []uint32) error {
+	buf = AppendUint32(buf[:0], uint32(len(src)))
+	buf = PackGroupUint32(buf, src)
+	_, err := w.Write(buf)
+	return err
+}
+
+// UnpackGroupUint32From reads values written by PackGroupUint32To from r and appends them to dst.
+//
+// buf is used as scratch space if it is large enough.
+// The memory used is bounded by the size of the data actually read, whatever the number of values
+// it announces.
+func UnpackGroupUint32From(r iobyte.ByteReader, buf []byte, dst []uint32) ([]uint32, error) {
+	count, err := UnpackUint32From(r, grow(buf[:0], 4)[:4])
+	if err != nil {
+		return dst, err
+	}
+	// The values must fit in memory, which int(count) may not on 32 bits platforms.
+	if uint64(count) > uint64(^uint(0)>>3) {
+		return dst, ErrGroupCount
+	}
+	n := int(count)
+	nctrl := GroupControlLen(n)
+	if buf, err = readChunks(r, buf[:0], nctrl); err != nil {
+		return dst, err
+	}
+	if buf, err = readChunks(r, buf, GroupDataLen(buf, n)); err != nil {
+		return dst, err
+	}
+	dst, _, err = UnpackGroupUint32(dst, buf, n)
+	return dst, err
+}
+
+// readChunks appends n bytes read from r to buf, growing it by at most groupChunk bytes
+// at a time.
+func readChunks(r io.Reader, buf []byte, n int) ([]byte, error) {
+	for n > 0 {
+		m := n
+		if m > groupChunk {
+			m = groupChunk
+		}
+		l := len(buf)
+		buf = grow(buf, m)[:l+m]
+		if _, err := io.ReadFull(r, buf[l:]); err != nil {
+			return buf, UnexpectedEOF(err)
+		}
+		n -= m
+	}
+	return buf, nil
+}
@@ -0,0 +1,150 @@
+package packuint
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"runtime"
+	"testing"
+)
+
+func TestPackGroupUint32(t *testing.T) {
+	for _, tc := range []struct {
+		label string
+		in    []uint32
+		out   []byte
+	}{
+		{"empty", nil, nil},
+		{"zero", []uint32{0}, []byte{0, 0}},
+		{"one group", []uint32{1, 0x100, 0x10000, 0x1000000}, []byte{0xE4, 1, 0, 1, 0, 0, 1, 0, 0, 0, 1}},
+		{"partial group", []uint32{0xFFFFFFFF, 2, 0x102, 3, 0xFF}, []byte{0x13, 0x00, 0xFF, 0xFF, 0xFF, 0xFF, 2, 2, 1, 3, 0xFF}},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			buf := PackGroupUint32(nil, tc.in)
+			if got, want := buf, tc.out; !bytes.Equal(got, want) {
+				t.Fatalf("got %x; want %x", got, want)
+			}
+			if got, want := PackedSizeGroupUint32(tc.in), len(buf); got != want {
+				t.Fatalf("got size %d; want %d", got, want)
+			}
+			values, n, err := UnpackGroupUint32(nil, buf, len(tc.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(buf) || !reflect.DeepEqual(values, tc.in) {
+				t.Fatalf("got %v in %d bytes; want %v in %d", values, n, tc.in, len(buf))
+			}
+		})
+	}
+}
+
+func TestUnpackGroupUint32(t *testing.T) {
+	var values []uint32
+	for _, x := range bulkValues(1001) {
+		values = append(values, uint32(x))
+	}
+	buf := PackGroupUint32([]byte{1}, values)
+	got, n, err := UnpackGroupUint32([]uint32{1}, buf[1:], len(values))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(buf)-1 || !reflect.DeepEqual(got[1:], values) {
+		t.Fatalf("got %d values in %d bytes; want %d in %d", len(got)-1, n, len(values), len(buf)-1)
+	}
+	if _, _, err := UnpackGroupUint32(nil, buf[1:], -1); err != ErrGroupCount {
+		t.Fatalf("got %v; want %v", err, ErrGroupCount)
+	}
+	for _, size := range []int{0, 100, len(buf) - 2} {
+		if _, _, err := UnpackGroupUint32(nil, buf[1:1+size], len(values)); err != io.ErrUnexpectedEOF {
+			t.Fatalf("%d bytes: got %v; want %v", size, err, io.ErrUnexpectedEOF)
+		}
+	}
+
+	// Streaming.
+	rw := new(bytes.Buffer)
+	for i := 0; i < 3; i++ {
+		if err := PackGroupUint32To(rw, nil, values[:i*100]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var scratch []byte
+	for i := 0; i < 3; i++ {
+		got, err := UnpackGroupUint32From(rw, scratch, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := values[:i*100]; len(got) != len(want) || len(got) > 0 && !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %d values; want %d", len(got), len(want))
+		}
+	}
+	if _, err := UnpackGroupUint32From(rw, scratch, nil); err != io.EOF {
+		t.Fatalf("got %v; want %v", err, io.EOF)
+	}
+}
+
+func TestUnpackGroupUint32FromHostileCount(t *testing.T) {
+	// The largest count followed by a few bytes only.
+	buf := AppendUint32(nil, 0xFFFFFFFF)
+	buf = append(buf, 1, 2, 3)
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := UnpackGroupUint32From(bytes.NewReader(buf), nil, nil); err != io.ErrUnexpectedEOF {
+		t.Fatalf("got %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+	runtime.ReadMemStats(&after)
+	if n := after.TotalAlloc - before.TotalAlloc; n > 1<<20 {
+		t.Fatalf("got %d bytes allocated; want at most 1MB", n)
+	}
+}
+
+var benchValues32 []uint32
+
+func BenchmarkPackGroupUint32(b *testing.B) {
+	values := make([]uint32, 1024)
+	for i, x := range bulkValues(len(values)) {
+		values[i] = uint32(x)
+	}
+	buf := make([]byte, 0, 5*len(values))
+	b.SetBytes(int64(4 * len(values)))
+	for i := 0; i < b.N; i++ {
+		benchBuf = PackGroupUint32(buf, values)
+	}
+}
+
+func BenchmarkPackGroupUint32Bitmap(b *testing.B) {
+	values := make([]uint32, 1024)
+	for i, x := range bulkValues(len(values)) {
+		values[i] = uint32(x)
+	}
+	buf := make([]byte, 0, 5*len(values))
+	b.SetBytes(int64(4 * len(values)))
+	for i := 0; i < b.N; i++ {
+		benchBuf = PackUint32s(buf, values)
+	}
+}
+
+func BenchmarkUnpackGroupUint32(b *testing.B) {
+	values := make([]uint32, 1024)
+	for i, x := range bulkValues(len(values)) {
+		values[i] = uint32(x)
+	}
+	buf := PackGroupUint32(nil, values)
+	b.SetBytes(int64(4 * len(values)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchValues32, benchInt, _ = UnpackGroupUint32(values[:0], buf, len(values))
+	}
+}
+
+func BenchmarkUnpackGroupUint32Bitmap(b *testing.B) {
+	values := make([]uint32, 1024)
+	for i, x := range bulkValues(len(values)) {
+		values[i] = uint32(x)
+	}
+	buf := PackUint32s(nil, values)
+	b.SetBytes(int64(4 * len(values)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		benchValues32, benchInt = UnpackUint32s(values[:0], buf)
+	}
+}
@@ -0,0 +1,63 @@
+package packuint
+
+import (
+	"io"
+
+	"github.com/pierrec/packer/iobyte"
+)
+
+// Signed integers are zigzag encoded before being packed so that values
+// of small magnitude have their high bytes unset whatever their sign:
+// 0, -1, 1, -2, 2... are mapped to 0, 1, 2, 3, 4...
+
+// Zigzag64 returns the zigzag encoding of x.
+func Zigzag64(x int64) uint64 { return uint64(x<<1) ^ uint64(x>>63) }
+
+// Unzigzag64 returns the value zigzag encoded in x.
+func Unzigzag64(x uint64) int64 { return int64(x>>1) ^ -int64(x&1) }
+
+// Zigzag32 returns the zigzag encoding of x.
+func Zigzag32(x int32) uint32 { return uint32(x<<1) ^ uint32(x>>31) }
+
+// Unzigzag32 returns the value zigzag encoded in x.
+func Unzigzag32(x uint32) int32 { return int32(x>>1) ^ -int32(x&1) }
+
+// PackInt64 packs x into buf and returns the number of bytes used.
+// buf must be at least 9 bytes long.
+func PackInt64(buf []byte, x int64) int {
+	return PackUint64(buf, Zigzag64(x))
+}
+
+func PackInt64To(w io.Writer, buf []byte, x int64) error {
+	return PackUint64To(w, buf, Zigzag64(x))
+}
+
+// UnpackInt64 unpacks buf and returns the value.
+func UnpackInt64(bitmap byte, buf []byte) int64 {
+	return Unzigzag64(UnpackUint64(bitmap, buf))
+}
+
+func UnpackInt64From(r iobyte.ByteReader, buf []byte) (int64, error) {
+	x, err := UnpackUint64From(r, buf)
+	return Unzigzag64(x), err
+}
+
+// PackInt32 packs x into buf and returns the number of bytes used.
+// buf must be at least 5 bytes long.
+func PackInt32(buf []byte, x int32) int {
+	return PackUint32(buf, Zigzag32(x))
+}
+
+func PackInt32To(w io.Writer, buf []byte, x int32) error {
+	return PackUint32To(w, buf, Zigzag32(x))
+}
+
+// UnpackInt32 unpacks buf and returns the value.
+func UnpackInt32(bitmap byte, buf []byte) int32 {
+	return Unzigzag32(UnpackUint32(bitmap, buf))
+}
+
+func UnpackInt32From(r iobyte.ByteReader, buf []byte) (int32, error) {
+	x, err := UnpackUint32From(r, buf)
+	return Unzigzag32(x), err
+}
@@ -0,0 +1,93 @@
+package packuint
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPackInt64(t *testing.T) {
+	for _, tc := range []struct {
+		x int64
+		n int // packed size
+	}{
+		{0, 1},
+		{-1, 2},
+		{1, 2},
+		{-64, 2},
+		{127, 2},
+		{-128, 2},
+		{-3200, 3},
+		{math.MaxInt64, 9},
+		{math.MinInt64, 9},
+	} {
+		label := fmt.Sprintf("%d", tc.x)
+		t.Run(label, func(t *testing.T) {
+			buf := make([]byte, 16)
+			n := PackInt64(buf, tc.x)
+			if n != tc.n {
+				t.Errorf("got %d bytes; want %d", n, tc.n)
+			}
+			if got, want := UnpackInt64(buf[0], buf[1:n]), tc.x; got != want {
+				t.Errorf("got %d; want %d", got, want)
+			}
+		})
+		t.Run("io "+label, func(t *testing.T) {
+			buf := make([]byte, 16)
+			rw := new(bytes.Buffer)
+			if err := PackInt64To(rw, buf, tc.x); err != nil {
+				t.Fatal(err)
+			}
+			x, err := UnpackInt64From(rw, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := x, tc.x; got != want {
+				t.Errorf("got %d; want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestPackInt32(t *testing.T) {
+	for _, tc := range []struct {
+		x int32
+		n int // packed size
+	}{
+		{0, 1},
+		{-1, 2},
+		{1, 2},
+		{-128, 2},
+		{127, 2},
+		{-3200, 3},
+		{math.MaxInt32, 5},
+		{math.MinInt32, 5},
+	} {
+		label := fmt.Sprintf("%d", tc.x)
+		t.Run(label, func(t *testing.T) {
+			buf := make([]byte, 16)
+			n := PackInt32(buf, tc.x)
+			if n != tc.n {
+				t.Errorf("got %d bytes; want %d", n, tc.n)
+			}
+			if got, want := UnpackInt32(buf[0], buf[1:n]), tc.x; got != want {
+				t.Errorf("got %d; want %d", got, want)
+			}
+		})
+		t.Run("io "+label, func(t *testing.T) {
+			buf := make([]byte, 16)
+			rw := new(bytes.Buffer)
+			if err := PackInt32To(rw, buf, tc.x); err != nil {
+				t.Fatal(err)
+			}
+			x, err := UnpackInt32From(rw, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := x, tc.x; got != want {
+				t.Errorf("got %d; want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package packuint
+
+import "math/bits"
+
+// PackedLenUint64 returns the number of bytes used by a packed uint64, bitmap included.
+func PackedLenUint64(bitmap byte) int {
+	return 1 + bits.OnesCount8(bitmap)
+}
+
+// PackedLenUint32 returns the number of bytes used by a packed uint32, bitmap included.
+func PackedLenUint32(bitmap byte) int {
+	// 1 or 2 nibbles per byte.
+	return 1 + (bits.OnesCount8(bitmap)+1)/2
+}
+
+// PackedSizeUint64 returns the number of bytes used to pack x.
+func PackedSizeUint64(x uint64) int {
+	// Fold every byte onto its lowest bit and count the non zero ones.
+	x |= x >> 4
+	x |= x >> 2
+	x |= x >> 1
+	return 1 + bits.OnesCount64(x&0x0101010101010101)
+}
+
+// PackedSizeUint32 returns the number of bytes used to pack x.
+func PackedSizeUint32(x uint32) int {
+	// Fold every nibble onto its lowest bit and count the non zero ones.
+	x |= x >> 2
+	x |= x >> 1
+	return 1 + (bits.OnesCount32(x&0x11111111)+1)/2
+}
+
+// PackedSizeInt64 returns the number of bytes used to pack x.
+func PackedSizeInt64(x int64) int {
+	return PackedSizeUint64(Zigzag64(x))
+}
+
+// PackedSizeInt32 returns the number of bytes used to pack x.
+func PackedSizeInt32(x int32) int {
+	return PackedSizeUint32(Zigzag32(x))
+}
+
+// AppendUint64 appends the packed x to dst and returns the extended buffer.
+func AppendUint64(dst []byte, x uint64) []byte {
+	n := len(dst)
+	if cap(dst)-n < 9 {
+		dst = append(dst, make([]byte, 9)...)
+	}
+	m := PackUint64(dst[n:n+9], x)
+	return dst[:n+m]
+}
+
+// AppendUint32 appends the packed x to dst and returns the extended buffer.
+func AppendUint32(dst []byte, x uint32) []byte {
+	n := len(dst)
+	if cap(dst)-n < 5 {
+		dst = append(dst, make([]byte, 5)...)
+	}
+	m := PackUint32(dst[n:n+5], x)
+	return dst[:n+m]
+}
+
+// AppendInt64 appends the packed x to dst and returns the extended buffer.
+func AppendInt64(dst []byte, x int64) []byte {
+	return AppendUint64(dst, Zigzag64(x))
+}
+
+// AppendInt32 appends the packed x to dst and returns the extended buffer.
+func AppendInt32(dst []byte, x int32) []byte {
+	return AppendUint32(dst, Zigzag32(x))
+}
@@ -0,0 +1,68 @@
+package packuint
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestPackedSize(t *testing.T) {
+	buf := make([]byte, 9)
+	for _, x := range []uint64{
+		0, 1, 15, 16, 255, 256, 1 << 10, 1 << 20, 1<<20 | 1<<10, 1 << 63,
+		0xF0F0F0F0, 0xF0F0F0F0F0F0F0F0, 0x0F00F000, math.MaxUint32, math.MaxUint64,
+		math.Float64bits(math.Pi),
+	} {
+		n := PackUint64(buf, x)
+		if got, want := PackedSizeUint64(x), n; got != want {
+			t.Errorf("uint64 %#x: got size %d; want %d", x, got, want)
+		}
+		if got, want := PackedLenUint64(buf[0]), n; got != want {
+			t.Errorf("uint64 %#x: got len %d; want %d", x, got, want)
+		}
+		if got, want := AppendUint64([]byte{1}, x), append([]byte{1}, buf[:n]...); !bytes.Equal(got, want) {
+			t.Errorf("uint64 %#x: got %x; want %x", x, got, want)
+		}
+
+		y := uint32(x)
+		n = PackUint32(buf, y)
+		if got, want := PackedSizeUint32(y), n; got != want {
+			t.Errorf("uint32 %#x: got size %d; want %d", y, got, want)
+		}
+		if got, want := PackedLenUint32(buf[0]), n; got != want {
+			t.Errorf("uint32 %#x: got len %d; want %d", y, got, want)
+		}
+		if got, want := AppendUint32([]byte{1}, y), append([]byte{1}, buf[:n]...); !bytes.Equal(got, want) {
+			t.Errorf("uint32 %#x: got %x; want %x", y, got, want)
+		}
+	}
+}
+
+func TestAppend(t *testing.T) {
+	var buf []byte
+	for i := int64(-1000); i < 1000; i += 7 {
+		buf = AppendInt64(buf, i*i*i)
+		buf = AppendInt32(buf, int32(i))
+	}
+	for i := int64(-1000); i < 1000; i += 7 {
+		n := PackedLenUint64(buf[0])
+		if got, want := UnpackInt64(buf[0], buf[1:n]), i*i*i; got != want {
+			t.Fatalf("got %d; want %d", got, want)
+		}
+		if got, want := n, PackedSizeInt64(i*i*i); got != want {
+			t.Fatalf("got %d bytes; want %d", got, want)
+		}
+		buf = buf[n:]
+		n = PackedLenUint32(buf[0])
+		if got, want := UnpackInt32(buf[0], buf[1:n]), int32(i); got != want {
+			t.Fatalf("got %d; want %d", got, want)
+		}
+		if got, want := n, PackedSizeInt32(int32(i)); got != want {
+			t.Fatalf("got %d bytes; want %d", got, want)
+		}
+		buf = buf[n:]
+	}
+	if len(buf) > 0 {
+		t.Fatalf("%d bytes left", len(buf))
+	}
+}
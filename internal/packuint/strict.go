@@ -0,0 +1,56 @@
+package packuint
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// The packed format allows several encodings of the same value: a byte (or nibble)
+// can be flagged in the bitmap and still be zero, or an odd number of nibbles
+// can leave garbage in the unused upper half of the last byte.
+// Pack functions never produce them and the strict unpack functions reject them.
+
+// ErrNonCanonical is returned by the strict unpack functions when the input
+// is not the encoding produced by the corresponding pack function.
+var ErrNonCanonical = errors.New("non canonical encoding")
+
+// UnpackUint64Strict is like UnpackUint64 but returns ErrNonCanonical if
+// bitmap and buf are not the canonical encoding of the value.
+func UnpackUint64Strict(bitmap byte, buf []byte) (uint64, error) {
+	n := bits.OnesCount8(bitmap)
+	for _, b := range buf[:n] {
+		if b == 0 {
+			return 0, ErrNonCanonical
+		}
+	}
+	return UnpackUint64(bitmap, buf), nil
+}
+
+// UnpackUint32Strict is like UnpackUint32 but returns ErrNonCanonical if
+// bitmap and buf are not the canonical encoding of the value.
+func UnpackUint32Strict(bitmap byte, buf []byte) (uint32, error) {
+	n := bits.OnesCount8(bitmap)
+	buf = buf[:(n+1)/2]
+	for i, b := range buf {
+		// The last byte only holds one nibble if n is odd.
+		half := n%2 == 1 && i == len(buf)-1
+		if b&0xF == 0 || (b>>4 == 0) != half {
+			return 0, ErrNonCanonical
+		}
+	}
+	return UnpackUint32(bitmap, buf), nil
+}
+
+// UnpackInt64Strict is like UnpackInt64 but returns ErrNonCanonical if
+// bitmap and buf are not the canonical encoding of the value.
+func UnpackInt64Strict(bitmap byte, buf []byte) (int64, error) {
+	x, err := UnpackUint64Strict(bitmap, buf)
+	return Unzigzag64(x), err
+}
+
+// UnpackInt32Strict is like UnpackInt32 but returns ErrNonCanonical if
+// bitmap and buf are not the canonical encoding of the value.
+func UnpackInt32Strict(bitmap byte, buf []byte) (int32, error) {
+	x, err := UnpackUint32Strict(bitmap, buf)
+	return Unzigzag32(x), err
+}
@@ -0,0 +1,80 @@
+package packuint
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUnpackStrict(t *testing.T) {
+	for _, tc := range []struct {
+		label string
+		width int
+		in    []byte
+		ok    bool
+	}{
+		{"64 zero", 64, []byte{0}, true},
+		{"64 one byte", 64, []byte{0x80, 1}, true},
+		{"64 zero byte", 64, []byte{0x80, 0}, false},
+		{"64 middle zero byte", 64, []byte{0xE0, 1, 0, 1}, false},
+		{"64 all bytes", 64, []byte{0xFF, 1, 2, 3, 4, 5, 6, 7, 8}, true},
+		{"64 all bytes zero", 64, []byte{0xFF, 1, 2, 3, 0, 5, 6, 7, 8}, false},
+		{"32 zero", 32, []byte{0}, true},
+		{"32 one nibble", 32, []byte{0x80, 0x01}, true},
+		{"32 zero nibble", 32, []byte{0x80, 0x00}, false},
+		{"32 unused nibble", 32, []byte{0x80, 0x21}, false},
+		{"32 two nibbles", 32, []byte{0xC0, 0x21}, true},
+		{"32 zero high nibble", 32, []byte{0xC0, 0x01}, false},
+		{"32 all nibbles", 32, []byte{0xFF, 0x21, 0x43, 0x65, 0x87}, true},
+		{"32 all nibbles zero", 32, []byte{0xFF, 0x21, 0x43, 0x05, 0x87}, false},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			var err error
+			var x interface{}
+			switch tc.width {
+			case 64:
+				x, err = UnpackUint64Strict(tc.in[0], tc.in[1:])
+			case 32:
+				x, err = UnpackUint32Strict(tc.in[0], tc.in[1:])
+			}
+			if tc.ok != (err == nil) {
+				t.Fatalf("got %v; want ok=%v", err, tc.ok)
+			}
+			if err != nil {
+				if err != ErrNonCanonical {
+					t.Fatalf("got %v; want %v", err, ErrNonCanonical)
+				}
+				return
+			}
+			// Canonical encodings re-encode to the same bytes.
+			buf := make([]byte, 9)
+			var n int
+			switch x := x.(type) {
+			case uint64:
+				n = PackUint64(buf, x)
+			case uint32:
+				n = PackUint32(buf, x)
+			}
+			if got, want := fmt.Sprintf("%x", buf[:n]), fmt.Sprintf("%x", tc.in); got != want {
+				t.Fatalf("got %s; want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestUnpackIntStrict(t *testing.T) {
+	buf := make([]byte, 9)
+	n := PackInt64(buf, -3200)
+	if x, err := UnpackInt64Strict(buf[0], buf[1:n]); err != nil || x != -3200 {
+		t.Fatalf("got %d, %v; want -3200", x, err)
+	}
+	n = PackInt32(buf, -3200)
+	if x, err := UnpackInt32Strict(buf[0], buf[1:n]); err != nil || x != -3200 {
+		t.Fatalf("got %d, %v; want -3200", x, err)
+	}
+	if _, err := UnpackInt64Strict(0x80, []byte{0}); err != ErrNonCanonical {
+		t.Fatalf("got %v; want %v", err, ErrNonCanonical)
+	}
+	if _, err := UnpackInt32Strict(0x80, []byte{0x10}); err != ErrNonCanonical {
+		t.Fatalf("got %v; want %v", err, ErrNonCanonical)
+	}
+}
@@ -3,7 +3,6 @@ package packuint
 import (
 	"encoding/binary"
 	"io"
-	"math/bits"
 
 	"github.com/pierrec/packer/iobyte"
 )
@@ -162,7 +161,7 @@ func UnpackUint64From(r iobyte.ByteReader, buf []byte) (x uint64, err error) {
 	if bitmap == 0 {
 		return
 	}
-	n := bits.OnesCount8(bitmap)
+	n := PackedLenUint64(bitmap) - 1
 	if n == 1 {
 		buf[0], err = r.ReadByte()
 	} else {
@@ -340,8 +339,7 @@ func UnpackUint32From(r iobyte.ByteReader, buf []byte) (x uint32, err error) {
 	if bitmap == 0 {
 		return
 	}
-	// 1 or 2 nibbles per byte.
-	n := (bits.OnesCount8(bitmap) + 1) / 2
+	n := PackedLenUint32(bitmap) - 1
 	if n == 1 {
 		buf[0], err = r.ReadByte()
 	} else {
@@ -1,39 +1,264 @@
-// Code generated by "uintgen.exe". DO NOT EDIT.
+// Code generated by `uintgen`. DO NOT EDIT.
+
 package packuint
 
-import "github.com/pierrec/packer/internal"
+import (
+	"github.com/pierrec/packer/internal"
+)
 
 var unpackTable = [...]internal.UintEntry{
-	57, 49, 498, 41, 490, 426, 4011, 33, 
-	482, 418, 4003, 354, 3939, 3427, 32100, 25, 
-	474, 410, 3995, 346, 3931, 3419, 32092, 282, 
-	3867, 3355, 32028, 2843, 31516, 27420, 256797, 17, 
-	466, 402, 3987, 338, 3923, 3411, 32084, 274, 
-	3859, 3347, 32020, 2835, 31508, 27412, 256789, 210, 
-	3795, 3283, 31956, 2771, 31444, 27348, 256725, 2259, 
-	30932, 26836, 256213, 22740, 252117, 219349, 2054358, 9, 
-	458, 394, 3979, 330, 3915, 3403, 32076, 266, 
-	3851, 3339, 32012, 2827, 31500, 27404, 256781, 202, 
-	3787, 3275, 31948, 2763, 31436, 27340, 256717, 2251, 
-	30924, 26828, 256205, 22732, 252109, 219341, 2054350, 138, 
-	3723, 3211, 31884, 2699, 31372, 27276, 256653, 2187, 
-	30860, 26764, 256141, 22668, 252045, 219277, 2054286, 1675, 
-	30348, 26252, 255629, 22156, 251533, 218765, 2053774, 18060, 
-	247437, 214669, 2049678, 181901, 2016910, 1754766, 16434831, 1, 
-	450, 386, 3971, 322, 3907, 3395, 32068, 258, 
-	3843, 3331, 32004, 2819, 31492, 27396, 256773, 194, 
-	3779, 3267, 31940, 2755, 31428, 27332, 256709, 2243, 
-	30916, 26820, 256197, 22724, 252101, 219333, 2054342, 130, 
-	3715, 3203, 31876, 2691, 31364, 27268, 256645, 2179, 
-	30852, 26756, 256133, 22660, 252037, 219269, 2054278, 1667, 
-	30340, 26244, 255621, 22148, 251525, 218757, 2053766, 18052, 
-	247429, 214661, 2049670, 181893, 2016902, 1754758, 16434823, 66, 
-	3651, 3139, 31812, 2627, 31300, 27204, 256581, 2115, 
-	30788, 26692, 256069, 22596, 251973, 219205, 2054214, 1603, 
-	30276, 26180, 255557, 22084, 251461, 218693, 2053702, 17988, 
-	247365, 214597, 2049606, 181829, 2016838, 1754694, 16434759, 1091, 
-	29764, 25668, 255045, 21572, 250949, 218181, 2053190, 17476, 
-	246853, 214085, 2049094, 181317, 2016326, 1754182, 16434247, 13380, 
-	242757, 209989, 2044998, 177221, 2012230, 1750086, 16430151, 144453, 
-	1979462, 1717318, 16397383, 1455174, 16135239, 14038087, 
+	0x00000039, // 0: Num=1 A=7 B=0 C=0 D=0 E=0 F=0 G=0
+	0x00000031, // 1: Num=1 A=6 B=0 C=0 D=0 E=0 F=0 G=0
+	0x000001F2, // 2: Num=2 A=6 B=7 C=0 D=0 E=0 F=0 G=0
+	0x00000029, // 3: Num=1 A=5 B=0 C=0 D=0 E=0 F=0 G=0
+	0x000001EA, // 4: Num=2 A=5 B=7 C=0 D=0 E=0 F=0 G=0
+	0x000001AA, // 5: Num=2 A=5 B=6 C=0 D=0 E=0 F=0 G=0
+	0x00000FAB, // 6: Num=3 A=5 B=6 C=7 D=0 E=0 F=0 G=0
+	0x00000021, // 7: Num=1 A=4 B=0 C=0 D=0 E=0 F=0 G=0
+	0x000001E2, // 8: Num=2 A=4 B=7 C=0 D=0 E=0 F=0 G=0
+	0x000001A2, // 9: Num=2 A=4 B=6 C=0 D=0 E=0 F=0 G=0
+	0x00000FA3, // 10: Num=3 A=4 B=6 C=7 D=0 E=0 F=0 G=0
+	0x00000162, // 11: Num=2 A=4 B=5 C=0 D=0 E=0 F=0 G=0
+	0x00000F63, // 12: Num=3 A=4 B=5 C=7 D=0 E=0 F=0 G=0
+	0x00000D63, // 13: Num=3 A=4 B=5 C=6 D=0 E=0 F=0 G=0
+	0x00007D64, // 14: Num=4 A=4 B=5 C=6 D=7 E=0 F=0 G=0
+	0x00000019, // 15: Num=1 A=3 B=0 C=0 D=0 E=0 F=0 G=0
+	0x000001DA, // 16: Num=2 A=3 B=7 C=0 D=0 E=0 F=0 G=0
+	0x0000019A, // 17: Num=2 A=3 B=6 C=0 D=0 E=0 F=0 G=0
+	0x00000F9B, // 18: Num=3 A=3 B=6 C=7 D=0 E=0 F=0 G=0
+	0x0000015A, // 19: Num=2 A=3 B=5 C=0 D=0 E=0 F=0 G=0
+	0x00000F5B, // 20: Num=3 A=3 B=5 C=7 D=0 E=0 F=0 G=0
+	0x00000D5B, // 21: Num=3 A=3 B=5 C=6 D=0 E=0 F=0 G=0
+	0x00007D5C, // 22: Num=4 A=3 B=5 C=6 D=7 E=0 F=0 G=0
+	0x0000011A, // 23: Num=2 A=3 B=4 C=0 D=0 E=0 F=0 G=0
+	0x00000F1B, // 24: Num=3 A=3 B=4 C=7 D=0 E=0 F=0 G=0
+	0x00000D1B, // 25: Num=3 A=3 B=4 C=6 D=0 E=0 F=0 G=0
+	0x00007D1C, // 26: Num=4 A=3 B=4 C=6 D=7 E=0 F=0 G=0
+	0x00000B1B, // 27: Num=3 A=3 B=4 C=5 D=0 E=0 F=0 G=0
+	0x00007B1C, // 28: Num=4 A=3 B=4 C=5 D=7 E=0 F=0 G=0
+	0x00006B1C, // 29: Num=4 A=3 B=4 C=5 D=6 E=0 F=0 G=0
+	0x0003EB1D, // 30: Num=5 A=3 B=4 C=5 D=6 E=7 F=0 G=0
+	0x00000011, // 31: Num=1 A=2 B=0 C=0 D=0 E=0 F=0 G=0
+	0x000001D2, // 32: Num=2 A=2 B=7 C=0 D=0 E=0 F=0 G=0
+	0x00000192, // 33: Num=2 A=2 B=6 C=0 D=0 E=0 F=0 G=0
+	0x00000F93, // 34: Num=3 A=2 B=6 C=7 D=0 E=0 F=0 G=0
+	0x00000152, // 35: Num=2 A=2 B=5 C=0 D=0 E=0 F=0 G=0
+	0x00000F53, // 36: Num=3 A=2 B=5 C=7 D=0 E=0 F=0 G=0
+	0x00000D53, // 37: Num=3 A=2 B=5 C=6 D=0 E=0 F=0 G=0
+	0x00007D54, // 38: Num=4 A=2 B=5 C=6 D=7 E=0 F=0 G=0
+	0x00000112, // 39: Num=2 A=2 B=4 C=0 D=0 E=0 F=0 G=0
+	0x00000F13, // 40: Num=3 A=2 B=4 C=7 D=0 E=0 F=0 G=0
+	0x00000D13, // 41: Num=3 A=2 B=4 C=6 D=0 E=0 F=0 G=0
+	0x00007D14, // 42: Num=4 A=2 B=4 C=6 D=7 E=0 F=0 G=0
+	0x00000B13, // 43: Num=3 A=2 B=4 C=5 D=0 E=0 F=0 G=0
+	0x00007B14, // 44: Num=4 A=2 B=4 C=5 D=7 E=0 F=0 G=0
+	0x00006B14, // 45: Num=4 A=2 B=4 C=5 D=6 E=0 F=0 G=0
+	0x0003EB15, // 46: Num=5 A=2 B=4 C=5 D=6 E=7 F=0 G=0
+	0x000000D2, // 47: Num=2 A=2 B=3 C=0 D=0 E=0 F=0 G=0
+	0x00000ED3, // 48: Num=3 A=2 B=3 C=7 D=0 E=0 F=0 G=0
+	0x00000CD3, // 49: Num=3 A=2 B=3 C=6 D=0 E=0 F=0 G=0
+	0x00007CD4, // 50: Num=4 A=2 B=3 C=6 D=7 E=0 F=0 G=0
+	0x00000AD3, // 51: Num=3 A=2 B=3 C=5 D=0 E=0 F=0 G=0
+	0x00007AD4, // 52: Num=4 A=2 B=3 C=5 D=7 E=0 F=0 G=0
+	0x00006AD4, // 53: Num=4 A=2 B=3 C=5 D=6 E=0 F=0 G=0
+	0x0003EAD5, // 54: Num=5 A=2 B=3 C=5 D=6 E=7 F=0 G=0
+	0x000008D3, // 55: Num=3 A=2 B=3 C=4 D=0 E=0 F=0 G=0
+	0x000078D4, // 56: Num=4 A=2 B=3 C=4 D=7 E=0 F=0 G=0
+	0x000068D4, // 57: Num=4 A=2 B=3 C=4 D=6 E=0 F=0 G=0
+	0x0003E8D5, // 58: Num=5 A=2 B=3 C=4 D=6 E=7 F=0 G=0
+	0x000058D4, // 59: Num=4 A=2 B=3 C=4 D=5 E=0 F=0 G=0
+	0x0003D8D5, // 60: Num=5 A=2 B=3 C=4 D=5 E=7 F=0 G=0
+	0x000358D5, // 61: Num=5 A=2 B=3 C=4 D=5 E=6 F=0 G=0
+	0x001F58D6, // 62: Num=6 A=2 B=3 C=4 D=5 E=6 F=7 G=0
+	0x00000009, // 63: Num=1 A=1 B=0 C=0 D=0 E=0 F=0 G=0
+	0x000001CA, // 64: Num=2 A=1 B=7 C=0 D=0 E=0 F=0 G=0
+	0x0000018A, // 65: Num=2 A=1 B=6 C=0 D=0 E=0 F=0 G=0
+	0x00000F8B, // 66: Num=3 A=1 B=6 C=7 D=0 E=0 F=0 G=0
+	0x0000014A, // 67: Num=2 A=1 B=5 C=0 D=0 E=0 F=0 G=0
+	0x00000F4B, // 68: Num=3 A=1 B=5 C=7 D=0 E=0 F=0 G=0
+	0x00000D4B, // 69: Num=3 A=1 B=5 C=6 D=0 E=0 F=0 G=0
+	0x00007D4C, // 70: Num=4 A=1 B=5 C=6 D=7 E=0 F=0 G=0
+	0x0000010A, // 71: Num=2 A=1 B=4 C=0 D=0 E=0 F=0 G=0
+	0x00000F0B, // 72: Num=3 A=1 B=4 C=7 D=0 E=0 F=0 G=0
+	0x00000D0B, // 73: Num=3 A=1 B=4 C=6 D=0 E=0 F=0 G=0
+	0x00007D0C, // 74: Num=4 A=1 B=4 C=6 D=7 E=0 F=0 G=0
+	0x00000B0B, // 75: Num=3 A=1 B=4 C=5 D=0 E=0 F=0 G=0
+	0x00007B0C, // 76: Num=4 A=1 B=4 C=5 D=7 E=0 F=0 G=0
+	0x00006B0C, // 77: Num=4 A=1 B=4 C=5 D=6 E=0 F=0 G=0
+	0x0003EB0D, // 78: Num=5 A=1 B=4 C=5 D=6 E=7 F=0 G=0
+	0x000000CA, // 79: Num=2 A=1 B=3 C=0 D=0 E=0 F=0 G=0
+	0x00000ECB, // 80: Num=3 A=1 B=3 C=7 D=0 E=0 F=0 G=0
+	0x00000CCB, // 81: Num=3 A=1 B=3 C=6 D=0 E=0 F=0 G=0
+	0x00007CCC, // 82: Num=4 A=1 B=3 C=6 D=7 E=0 F=0 G=0
+	0x00000ACB, // 83: Num=3 A=1 B=3 C=5 D=0 E=0 F=0 G=0
+	0x00007ACC, // 84: Num=4 A=1 B=3 C=5 D=7 E=0 F=0 G=0
+	0x00006ACC, // 85: Num=4 A=1 B=3 C=5 D=6 E=0 F=0 G=0
+	0x0003EACD, // 86: Num=5 A=1 B=3 C=5 D=6 E=7 F=0 G=0
+	0x000008CB, // 87: Num=3 A=1 B=3 C=4 D=0 E=0 F=0 G=0
+	0x000078CC, // 88: Num=4 A=1 B=3 C=4 D=7 E=0 F=0 G=0
+	0x000068CC, // 89: Num=4 A=1 B=3 C=4 D=6 E=0 F=0 G=0
+	0x0003E8CD, // 90: Num=5 A=1 B=3 C=4 D=6 E=7 F=0 G=0
+	0x000058CC, // 91: Num=4 A=1 B=3 C=4 D=5 E=0 F=0 G=0
+	0x0003D8CD, // 92: Num=5 A=1 B=3 C=4 D=5 E=7 F=0 G=0
+	0x000358CD, // 93: Num=5 A=1 B=3 C=4 D=5 E=6 F=0 G=0
+	0x001F58CE, // 94: Num=6 A=1 B=3 C=4 D=5 E=6 F=7 G=0
+	0x0000008A, // 95: Num=2 A=1 B=2 C=0 D=0 E=0 F=0 G=0
+	0x00000E8B, // 96: Num=3 A=1 B=2 C=7 D=0 E=0 F=0 G=0
+	0x00000C8B, // 97: Num=3 A=1 B=2 C=6 D=0 E=0 F=0 G=0
+	0x00007C8C, // 98: Num=4 A=1 B=2 C=6 D=7 E=0 F=0 G=0
+	0x00000A8B, // 99: Num=3 A=1 B=2 C=5 D=0 E=0 F=0 G=0
+	0x00007A8C, // 100: Num=4 A=1 B=2 C=5 D=7 E=0 F=0 G=0
+	0x00006A8C, // 101: Num=4 A=1 B=2 C=5 D=6 E=0 F=0 G=0
+	0x0003EA8D, // 102: Num=5 A=1 B=2 C=5 D=6 E=7 F=0 G=0
+	0x0000088B, // 103: Num=3 A=1 B=2 C=4 D=0 E=0 F=0 G=0
+	0x0000788C, // 104: Num=4 A=1 B=2 C=4 D=7 E=0 F=0 G=0
+	0x0000688C, // 105: Num=4 A=1 B=2 C=4 D=6 E=0 F=0 G=0
+	0x0003E88D, // 106: Num=5 A=1 B=2 C=4 D=6 E=7 F=0 G=0
+	0x0000588C, // 107: Num=4 A=1 B=2 C=4 D=5 E=0 F=0 G=0
+	0x0003D88D, // 108: Num=5 A=1 B=2 C=4 D=5 E=7 F=0 G=0
+	0x0003588D, // 109: Num=5 A=1 B=2 C=4 D=5 E=6 F=0 G=0
+	0x001F588E, // 110: Num=6 A=1 B=2 C=4 D=5 E=6 F=7 G=0
+	0x0000068B, // 111: Num=3 A=1 B=2 C=3 D=0 E=0 F=0 G=0
+	0x0000768C, // 112: Num=4 A=1 B=2 C=3 D=7 E=0 F=0 G=0
+	0x0000668C, // 113: Num=4 A=1 B=2 C=3 D=6 E=0 F=0 G=0
+	0x0003E68D, // 114: Num=5 A=1 B=2 C=3 D=6 E=7 F=0 G=0
+	0x0000568C, // 115: Num=4 A=1 B=2 C=3 D=5 E=0 F=0 G=0
+	0x0003D68D, // 116: Num=5 A=1 B=2 C=3 D=5 E=7 F=0 G=0
+	0x0003568D, // 117: Num=5 A=1 B=2 C=3 D=5 E=6 F=0 G=0
+	0x001F568E, // 118: Num=6 A=1 B=2 C=3 D=5 E=6 F=7 G=0
+	0x0000468C, // 119: Num=4 A=1 B=2 C=3 D=4 E=0 F=0 G=0
+	0x0003C68D, // 120: Num=5 A=1 B=2 C=3 D=4 E=7 F=0 G=0
+	0x0003468D, // 121: Num=5 A=1 B=2 C=3 D=4 E=6 F=0 G=0
+	0x001F468E, // 122: Num=6 A=1 B=2 C=3 D=4 E=6 F=7 G=0
+	0x0002C68D, // 123: Num=5 A=1 B=2 C=3 D=4 E=5 F=0 G=0
+	0x001EC68E, // 124: Num=6 A=1 B=2 C=3 D=4 E=5 F=7 G=0
+	0x001AC68E, // 125: Num=6 A=1 B=2 C=3 D=4 E=5 F=6 G=0
+	0x00FAC68F, // 126: Num=7 A=1 B=2 C=3 D=4 E=5 F=6 G=7
+	0x00000001, // 127: Num=1 A=0 B=0 C=0 D=0 E=0 F=0 G=0
+	0x000001C2, // 128: Num=2 A=0 B=7 C=0 D=0 E=0 F=0 G=0
+	0x00000182, // 129: Num=2 A=0 B=6 C=0 D=0 E=0 F=0 G=0
+	0x00000F83, // 130: Num=3 A=0 B=6 C=7 D=0 E=0 F=0 G=0
+	0x00000142, // 131: Num=2 A=0 B=5 C=0 D=0 E=0 F=0 G=0
+	0x00000F43, // 132: Num=3 A=0 B=5 C=7 D=0 E=0 F=0 G=0
+	0x00000D43, // 133: Num=3 A=0 B=5 C=6 D=0 E=0 F=0 G=0
+	0x00007D44, // 134: Num=4 A=0 B=5 C=6 D=7 E=0 F=0 G=0
+	0x00000102, // 135: Num=2 A=0 B=4 C=0 D=0 E=0 F=0 G=0
+	0x00000F03, // 136: Num=3 A=0 B=4 C=7 D=0 E=0 F=0 G=0
+	0x00000D03, // 137: Num=3 A=0 B=4 C=6 D=0 E=0 F=0 G=0
+	0x00007D04, // 138: Num=4 A=0 B=4 C=6 D=7 E=0 F=0 G=0
+	0x00000B03, // 139: Num=3 A=0 B=4 C=5 D=0 E=0 F=0 G=0
+	0x00007B04, // 140: Num=4 A=0 B=4 C=5 D=7 E=0 F=0 G=0
+	0x00006B04, // 141: Num=4 A=0 B=4 C=5 D=6 E=0 F=0 G=0
+	0x0003EB05, // 142: Num=5 A=0 B=4 C=5 D=6 E=7 F=0 G=0
+	0x000000C2, // 143: Num=2 A=0 B=3 C=0 D=0 E=0 F=0 G=0
+	0x00000EC3, // 144: Num=3 A=0 B=3 C=7 D=0 E=0 F=0 G=0
+	0x00000CC3, // 145: Num=3 A=0 B=3 C=6 D=0 E=0 F=0 G=0
+	0x00007CC4, // 146: Num=4 A=0 B=3 C=6 D=7 E=0 F=0 G=0
+	0x00000AC3, // 147: Num=3 A=0 B=3 C=5 D=0 E=0 F=0 G=0
+	0x00007AC4, // 148: Num=4 A=0 B=3 C=5 D=7 E=0 F=0 G=0
+	0x00006AC4, // 149: Num=4 A=0 B=3 C=5 D=6 E=0 F=0 G=0
+	0x0003EAC5, // 150: Num=5 A=0 B=3 C=5 D=6 E=7 F=0 G=0
+	0x000008C3, // 151: Num=3 A=0 B=3 C=4 D=0 E=0 F=0 G=0
+	0x000078C4, // 152: Num=4 A=0 B=3 C=4 D=7 E=0 F=0 G=0
+	0x000068C4, // 153: Num=4 A=0 B=3 C=4 D=6 E=0 F=0 G=0
+	0x0003E8C5, // 154: Num=5 A=0 B=3 C=4 D=6 E=7 F=0 G=0
+	0x000058C4, // 155: Num=4 A=0 B=3 C=4 D=5 E=0 F=0 G=0
+	0x0003D8C5, // 156: Num=5 A=0 B=3 C=4 D=5 E=7 F=0 G=0
+	0x000358C5, // 157: Num=5 A=0 B=3 C=4 D=5 E=6 F=0 G=0
+	0x001F58C6, // 158: Num=6 A=0 B=3 C=4 D=5 E=6 F=7 G=0
+	0x00000082, // 159: Num=2 A=0 B=2 C=0 D=0 E=0 F=0 G=0
+	0x00000E83, // 160: Num=3 A=0 B=2 C=7 D=0 E=0 F=0 G=0
+	0x00000C83, // 161: Num=3 A=0 B=2 C=6 D=0 E=0 F=0 G=0
+	0x00007C84, // 162: Num=4 A=0 B=2 C=6 D=7 E=0 F=0 G=0
+	0x00000A83, // 163: Num=3 A=0 B=2 C=5 D=0 E=0 F=0 G=0
+	0x00007A84, // 164: Num=4 A=0 B=2 C=5 D=7 E=0 F=0 G=0
+	0x00006A84, // 165: Num=4 A=0 B=2 C=5 D=6 E=0 F=0 G=0
+	0x0003EA85, // 166: Num=5 A=0 B=2 C=5 D=6 E=7 F=0 G=0
+	0x00000883, // 167: Num=3 A=0 B=2 C=4 D=0 E=0 F=0 G=0
+	0x00007884, // 168: Num=4 A=0 B=2 C=4 D=7 E=0 F=0 G=0
+	0x00006884, // 169: Num=4 A=0 B=2 C=4 D=6 E=0 F=0 G=0
+	0x0003E885, // 170: Num=5 A=0 B=2 C=4 D=6 E=7 F=0 G=0
+	0x00005884, // 171: Num=4 A=0 B=2 C=4 D=5 E=0 F=0 G=0
+	0x0003D885, // 172: Num=5 A=0 B=2 C=4 D=5 E=7 F=0 G=0
+	0x00035885, // 173: Num=5 A=0 B=2 C=4 D=5 E=6 F=0 G=0
+	0x001F5886, // 174: Num=6 A=0 B=2 C=4 D=5 E=6 F=7 G=0
+	0x00000683, // 175: Num=3 A=0 B=2 C=3 D=0 E=0 F=0 G=0
+	0x00007684, // 176: Num=4 A=0 B=2 C=3 D=7 E=0 F=0 G=0
+	0x00006684, // 177: Num=4 A=0 B=2 C=3 D=6 E=0 F=0 G=0
+	0x0003E685, // 178: Num=5 A=0 B=2 C=3 D=6 E=7 F=0 G=0
+	0x00005684, // 179: Num=4 A=0 B=2 C=3 D=5 E=0 F=0 G=0
+	0x0003D685, // 180: Num=5 A=0 B=2 C=3 D=5 E=7 F=0 G=0
+	0x00035685, // 181: Num=5 A=0 B=2 C=3 D=5 E=6 F=0 G=0
+	0x001F5686, // 182: Num=6 A=0 B=2 C=3 D=5 E=6 F=7 G=0
+	0x00004684, // 183: Num=4 A=0 B=2 C=3 D=4 E=0 F=0 G=0
+	0x0003C685, // 184: Num=5 A=0 B=2 C=3 D=4 E=7 F=0 G=0
+	0x00034685, // 185: Num=5 A=0 B=2 C=3 D=4 E=6 F=0 G=0
+	0x001F4686, // 186: Num=6 A=0 B=2 C=3 D=4 E=6 F=7 G=0
+	0x0002C685, // 187: Num=5 A=0 B=2 C=3 D=4 E=5 F=0 G=0
+	0x001EC686, // 188: Num=6 A=0 B=2 C=3 D=4 E=5 F=7 G=0
+	0x001AC686, // 189: Num=6 A=0 B=2 C=3 D=4 E=5 F=6 G=0
+	0x00FAC687, // 190: Num=7 A=0 B=2 C=3 D=4 E=5 F=6 G=7
+	0x00000042, // 191: Num=2 A=0 B=1 C=0 D=0 E=0 F=0 G=0
+	0x00000E43, // 192: Num=3 A=0 B=1 C=7 D=0 E=0 F=0 G=0
+	0x00000C43, // 193: Num=3 A=0 B=1 C=6 D=0 E=0 F=0 G=0
+	0x00007C44, // 194: Num=4 A=0 B=1 C=6 D=7 E=0 F=0 G=0
+	0x00000A43, // 195: Num=3 A=0 B=1 C=5 D=0 E=0 F=0 G=0
+	0x00007A44, // 196: Num=4 A=0 B=1 C=5 D=7 E=0 F=0 G=0
+	0x00006A44, // 197: Num=4 A=0 B=1 C=5 D=6 E=0 F=0 G=0
+	0x0003EA45, // 198: Num=5 A=0 B=1 C=5 D=6 E=7 F=0 G=0
+	0x00000843, // 199: Num=3 A=0 B=1 C=4 D=0 E=0 F=0 G=0
+	0x00007844, // 200: Num=4 A=0 B=1 C=4 D=7 E=0 F=0 G=0
+	0x00006844, // 201: Num=4 A=0 B=1 C=4 D=6 E=0 F=0 G=0
+	0x0003E845, // 202: Num=5 A=0 B=1 C=4 D=6 E=7 F=0 G=0
+	0x00005844, // 203: Num=4 A=0 B=1 C=4 D=5 E=0 F=0 G=0
+	0x0003D845, // 204: Num=5 A=0 B=1 C=4 D=5 E=7 F=0 G=0
+	0x00035845, // 205: Num=5 A=0 B=1 C=4 D=5 E=6 F=0 G=0
+	0x001F5846, // 206: Num=6 A=0 B=1 C=4 D=5 E=6 F=7 G=0
+	0x00000643, // 207: Num=3 A=0 B=1 C=3 D=0 E=0 F=0 G=0
+	0x00007644, // 208: Num=4 A=0 B=1 C=3 D=7 E=0 F=0 G=0
+	0x00006644, // 209: Num=4 A=0 B=1 C=3 D=6 E=0 F=0 G=0
+	0x0003E645, // 210: Num=5 A=0 B=1 C=3 D=6 E=7 F=0 G=0
+	0x00005644, // 211: Num=4 A=0 B=1 C=3 D=5 E=0 F=0 G=0
+	0x0003D645, // 212: Num=5 A=0 B=1 C=3 D=5 E=7 F=0 G=0
+	0x00035645, // 213: Num=5 A=0 B=1 C=3 D=5 E=6 F=0 G=0
+	0x001F5646, // 214: Num=6 A=0 B=1 C=3 D=5 E=6 F=7 G=0
+	0x00004644, // 215: Num=4 A=0 B=1 C=3 D=4 E=0 F=0 G=0
+	0x0003C645, // 216: Num=5 A=0 B=1 C=3 D=4 E=7 F=0 G=0
+	0x00034645, // 217: Num=5 A=0 B=1 C=3 D=4 E=6 F=0 G=0
+	0x001F4646, // 218: Num=6 A=0 B=1 C=3 D=4 E=6 F=7 G=0
+	0x0002C645, // 219: Num=5 A=0 B=1 C=3 D=4 E=5 F=0 G=0
+	0x001EC646, // 220: Num=6 A=0 B=1 C=3 D=4 E=5 F=7 G=0
+	0x001AC646, // 221: Num=6 A=0 B=1 C=3 D=4 E=5 F=6 G=0
+	0x00FAC647, // 222: Num=7 A=0 B=1 C=3 D=4 E=5 F=6 G=7
+	0x00000443, // 223: Num=3 A=0 B=1 C=2 D=0 E=0 F=0 G=0
+	0x00007444, // 224: Num=4 A=0 B=1 C=2 D=7 E=0 F=0 G=0
+	0x00006444, // 225: Num=4 A=0 B=1 C=2 D=6 E=0 F=0 G=0
+	0x0003E445, // 226: Num=5 A=0 B=1 C=2 D=6 E=7 F=0 G=0
+	0x00005444, // 227: Num=4 A=0 B=1 C=2 D=5 E=0 F=0 G=0
+	0x0003D445, // 228: Num=5 A=0 B=1 C=2 D=5 E=7 F=0 G=0
+	0x00035445, // 229: Num=5 A=0 B=1 C=2 D=5 E=6 F=0 G=0
+	0x001F5446, // 230: Num=6 A=0 B=1 C=2 D=5 E=6 F=7 G=0
+	0x00004444, // 231: Num=4 A=0 B=1 C=2 D=4 E=0 F=0 G=0
+	0x0003C445, // 232: Num=5 A=0 B=1 C=2 D=4 E=7 F=0 G=0
+	0x00034445, // 233: Num=5 A=0 B=1 C=2 D=4 E=6 F=0 G=0
+	0x001F4446, // 234: Num=6 A=0 B=1 C=2 D=4 E=6 F=7 G=0
+	0x0002C445, // 235: Num=5 A=0 B=1 C=2 D=4 E=5 F=0 G=0
+	0x001EC446, // 236: Num=6 A=0 B=1 C=2 D=4 E=5 F=7 G=0
+	0x001AC446, // 237: Num=6 A=0 B=1 C=2 D=4 E=5 F=6 G=0
+	0x00FAC447, // 238: Num=7 A=0 B=1 C=2 D=4 E=5 F=6 G=7
+	0x00003444, // 239: Num=4 A=0 B=1 C=2 D=3 E=0 F=0 G=0
+	0x0003B445, // 240: Num=5 A=0 B=1 C=2 D=3 E=7 F=0 G=0
+	0x00033445, // 241: Num=5 A=0 B=1 C=2 D=3 E=6 F=0 G=0
+	0x001F3446, // 242: Num=6 A=0 B=1 C=2 D=3 E=6 F=7 G=0
+	0x0002B445, // 243: Num=5 A=0 B=1 C=2 D=3 E=5 F=0 G=0
+	0x001EB446, // 244: Num=6 A=0 B=1 C=2 D=3 E=5 F=7 G=0
+	0x001AB446, // 245: Num=6 A=0 B=1 C=2 D=3 E=5 F=6 G=0
+	0x00FAB447, // 246: Num=7 A=0 B=1 C=2 D=3 E=5 F=6 G=7
+	0x00023445, // 247: Num=5 A=0 B=1 C=2 D=3 E=4 F=0 G=0
+	0x001E3446, // 248: Num=6 A=0 B=1 C=2 D=3 E=4 F=7 G=0
+	0x001A3446, // 249: Num=6 A=0 B=1 C=2 D=3 E=4 F=6 G=0
+	0x00FA3447, // 250: Num=7 A=0 B=1 C=2 D=3 E=4 F=6 G=7
+	0x00163446, // 251: Num=6 A=0 B=1 C=2 D=3 E=4 F=5 G=0
+	0x00F63447, // 252: Num=7 A=0 B=1 C=2 D=3 E=4 F=5 G=7
+	0x00D63447, // 253: Num=7 A=0 B=1 C=2 D=3 E=4 F=5 G=6
 }
@@ -16,6 +16,9 @@ package internal
 //   (unused)  8
 type UintEntry uint32
 
+// UintEntryFingerprint is the fingerprint of the layout of UintEntry, see packer.CheckFingerprint.
+const UintEntryFingerprint = "d55df8c277310b96"
+
 // Getters.
 func (x UintEntry) Num() uint8 { return uint8(x&0x7) }
 func (x UintEntry) A() uint8 { return uint8(x>>3&0x7) }
@@ -0,0 +1,162 @@
+package yamlite
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// ParseJSON parses the JSON document in data into nodes typed as their YAML equivalent:
+// strings are quoted scalars while numbers, booleans and null are plain ones.
+func ParseJSON(data []byte) (*Node, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	p := &jsonParser{dec: dec, data: data}
+	n, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	line, col := p.pos(dec.InputOffset())
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, &Error{line, col, "unexpected content after the document"}
+	}
+	return n, nil
+}
+
+type jsonParser struct {
+	dec  *json.Decoder
+	data []byte
+}
+
+// pos returns the line and column of the given offset, skipping spaces and separators.
+func (p *jsonParser) pos(offset int64) (line, col int) {
+	for offset < int64(len(p.data)) && bytes.IndexByte([]byte(" \t\r\n,:"), p.data[offset]) >= 0 {
+		offset++
+	}
+	line, col = 1, 1
+	for _, c := range p.data[:offset] {
+		if c == '\n' {
+			line, col = line+1, 1
+		} else {
+			col++
+		}
+	}
+	return
+}
+
+// token returns the next token and the position of its start.
+func (p *jsonParser) token() (json.Token, int, int, error) {
+	line, col := p.pos(p.dec.InputOffset())
+	t, err := p.dec.Token()
+	if err != nil {
+		msg := err.Error()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			msg = "unexpected end of JSON input"
+		}
+		return nil, line, col, &Error{line, col, msg}
+	}
+	return t, line, col, nil
+}
+
+func (p *jsonParser) value() (*Node, error) {
+	t, line, col, err := p.token()
+	if err != nil {
+		return nil, err
+	}
+	n := &Node{Kind: Scalar, Line: line, Col: col}
+	switch t := t.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			n.Kind = Mapping
+			for p.dec.More() {
+				t, line, col, err := p.token()
+				if err != nil {
+					return nil, err
+				}
+				key := &Node{Kind: Scalar, Line: line, Col: col, Value: t.(string), Quoted: true}
+				if n.Get(key.Value) != nil {
+					return nil, &Error{line, col, fmt.Sprintf("duplicate key %q", key.Value)}
+				}
+				v, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.Keys = append(n.Keys, key)
+				n.Values = append(n.Values, v)
+			}
+		case '[':
+			n.Kind = Sequence
+			for p.dec.More() {
+				v, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.Items = append(n.Items, v)
+			}
+		}
+		// Closing delimiter.
+		if _, _, _, err := p.token(); err != nil {
+			return nil, err
+		}
+	case string:
+		n.Value, n.Quoted = t, true
+	case json.Number:
+		n.Value = t.String()
+	case bool:
+		n.Value = strconv.FormatBool(t)
+	case nil:
+		n.Value = "null"
+	}
+	return n, nil
+}
+
+var (
+	yamlInt   = regexp.MustCompile(`^([-+]?[0-9]+|0o[0-7]+|0x[0-9a-fA-F]+)$`)
+	yamlFloat = regexp.MustCompile(`^([-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?|[-+]?\.(inf|Inf|INF)|\.(nan|NaN|NAN))$`)
+)
+
+// Type returns the JSON type of the node: null, boolean, integer, number, string, object or array.
+// Plain scalars are resolved as defined by the YAML core schema.
+func (n *Node) Type() string {
+	switch {
+	case n.Kind == Mapping:
+		return "object"
+	case n.Kind == Sequence:
+		return "array"
+	case n.Quoted:
+		return "string"
+	case n.IsNull():
+		return "null"
+	}
+	switch n.Value {
+	case "true", "True", "TRUE", "false", "False", "FALSE":
+		return "boolean"
+	}
+	switch {
+	case yamlInt.MatchString(n.Value):
+		return "integer"
+	case yamlFloat.MatchString(n.Value):
+		return "number"
+	}
+	return "string"
+}
+
+// Int returns the value of an integer node.
+func (n *Node) Int() (int64, error) {
+	if n.Type() != "integer" {
+		return 0, fmt.Errorf("%q is not an integer", n.Value)
+	}
+	return strconv.ParseInt(n.Value, 0, 64)
+}
+
+// Bool returns the value of a boolean node.
+func (n *Node) Bool() (bool, error) {
+	if n.Type() != "boolean" {
+		return false, fmt.Errorf("%q is not a boolean", n.Value)
+	}
+	return n.Value[0] == 't' || n.Value[0] == 'T', nil
+}
@@ -0,0 +1,96 @@
+package yamlite
+
+import "testing"
+
+func TestParseJSON(t *testing.T) {
+	for _, tc := range []struct {
+		label, in, out string
+	}{
+		{"scalars", `["a", 1, -2.5e3, true, null]`, `["a" 1 -2.5e3 true null]`},
+		{"object", `{"a": {"b": [1, {}]}, "c": "d"}`, `{a:{b:[1 {}]} c:"d"}`},
+		{"empty", " [ ] ", "[]"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			n, err := ParseJSON([]byte(tc.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := dump(n), tc.out; got != want {
+				t.Fatalf("got %s; want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestParseJSONPosition(t *testing.T) {
+	n, err := ParseJSON([]byte("{\n  \"a\": [\n    1,\n    \"x\"\n  ]\n}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := n.Get("a")
+	for _, tc := range []struct {
+		n         *Node
+		line, col int
+	}{
+		{n, 1, 1},
+		{n.Keys[0], 2, 3},
+		{a, 2, 8},
+		{a.Items[0], 3, 5},
+		{a.Items[1], 4, 5},
+	} {
+		if tc.n.Line != tc.line || tc.n.Col != tc.col {
+			t.Fatalf("%q: got %d:%d; want %d:%d", tc.n.Value, tc.n.Line, tc.n.Col, tc.line, tc.col)
+		}
+	}
+}
+
+func TestParseJSONError(t *testing.T) {
+	for _, tc := range []struct {
+		label, in, err string
+	}{
+		{"unterminated", `{"a": 1`, "1:8: unexpected end of JSON input"},
+		{"duplicate", `{"a": 1, "a": 2}`, `1:10: duplicate key "a"`},
+		{"trailing", `{} []`, "1:4: unexpected content after the document"},
+		{"trailing delimiter", `{}]`, "1:3: unexpected content after the document"},
+		{"invalid", "[1,\n x]", "2:2: invalid character 'x' looking for beginning of value"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			_, err := ParseJSON([]byte(tc.in))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("got %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestType(t *testing.T) {
+	for _, tc := range []struct {
+		in, typ string
+	}{
+		{"a: ", "null"},
+		{"a: ~", "null"},
+		{"a: true", "boolean"},
+		{"a: False", "boolean"},
+		{"a: 12", "integer"},
+		{"a: -0x1F", "string"},
+		{"a: 0x1F", "integer"},
+		{"a: 0o17", "integer"},
+		{"a: 1.5", "number"},
+		{"a: .inf", "number"},
+		{"a: '12'", "string"},
+		{"a: x", "string"},
+		{"a: []", "array"},
+		{"a: {}", "object"},
+	} {
+		n, err := Parse([]byte(tc.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := n.Get("a").Type(); got != tc.typ {
+			t.Fatalf("%s: got %s; want %s", tc.in, got, tc.typ)
+		}
+	}
+}
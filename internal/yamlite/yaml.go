@@ -0,0 +1,469 @@
+// Package yamlite parses the subset of YAML used by description files:
+// block mappings and sequences, flow sequences of scalars and plain, quoted or block scalars.
+// Anchors, aliases, tags, flow mappings and multiple documents are not supported.
+//
+// Nodes keep their position in the source so that errors can be reported precisely.
+package yamlite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of a node.
+type Kind uint8
+
+// Node kinds.
+const (
+	Scalar Kind = iota
+	Mapping
+	Sequence
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Mapping:
+		return "mapping"
+	case Sequence:
+		return "sequence"
+	}
+	return "scalar"
+}
+
+// Node is a parsed YAML node.
+type Node struct {
+	Kind   Kind
+	Line   int     // line of the node, starting at 1
+	Col    int     // column of the node, starting at 1
+	Value  string  // scalar value
+	Quoted bool    // the scalar was quoted or is a block scalar
+	Items  []*Node // sequence items
+	Keys   []*Node // mapping keys
+	Values []*Node // mapping values, in the order of Keys
+}
+
+// IsNull reports whether the node is an empty or null scalar.
+func (n *Node) IsNull() bool {
+	if n.Kind != Scalar || n.Quoted {
+		return false
+	}
+	switch n.Value {
+	case "", "~", "null", "Null", "NULL":
+		return true
+	}
+	return false
+}
+
+// Get returns the value of the mapping n for the given key, or nil if not found.
+func (n *Node) Get(key string) *Node {
+	for i, k := range n.Keys {
+		if k.Value == key {
+			return n.Values[i]
+		}
+	}
+	return nil
+}
+
+// Error is returned for invalid or unsupported YAML.
+type Error struct {
+	Line, Col int
+	Msg       string
+}
+
+func (e *Error) Error() string { return fmt.Sprintf("%d:%d: %s", e.Line, e.Col, e.Msg) }
+
+// line is a line of the source.
+type line struct {
+	num    int
+	indent int    // number of leading spaces
+	text   string // content without indentation nor comment
+	raw    string
+}
+
+type parser struct {
+	lines []line
+	i     int
+}
+
+// Parse parses the YAML document in data.
+func Parse(data []byte) (*Node, error) {
+	p := &parser{}
+	for i, raw := range strings.Split(string(data), "\n") {
+		raw = strings.TrimSuffix(raw, "\r")
+		l := line{num: i + 1, raw: raw}
+		text := strings.TrimLeft(raw, " ")
+		l.indent = len(raw) - len(text)
+		if strings.HasPrefix(text, "\t") {
+			return nil, &Error{l.num, l.indent + 1, "tabs cannot be used for indentation"}
+		}
+		l.text = strings.TrimRight(stripComment(text), " \t")
+		p.lines = append(p.lines, l)
+	}
+
+	// Document markers.
+	for k := range p.lines {
+		l := &p.lines[k]
+		if l.indent > 0 {
+			continue
+		}
+		switch {
+		case l.text == "---" && p.next() == k:
+			l.text = ""
+		case l.text == "---":
+			return nil, &Error{l.num, 1, "multiple documents are not supported"}
+		case l.text == "...":
+			for j := k; j < len(p.lines); j++ {
+				p.lines[j].text = ""
+			}
+		case strings.HasPrefix(l.text, "%"):
+			return nil, &Error{l.num, 1, "directives are not supported"}
+		}
+	}
+
+	k := p.next()
+	if k == len(p.lines) {
+		return &Node{Kind: Scalar, Line: 1, Col: 1}, nil
+	}
+	n, err := p.block(p.lines[k].indent)
+	if err != nil {
+		return nil, err
+	}
+	if k := p.next(); k < len(p.lines) {
+		l := p.lines[k]
+		return nil, &Error{l.num, l.indent + 1, "unexpected content"}
+	}
+	return n, nil
+}
+
+// stripComment removes the comment from the line, if any.
+func stripComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			if i == 0 || strings.IndexByte(" [,:-", s[i-1]) >= 0 {
+				quote = c
+			}
+		case c == '#':
+			if i == 0 || s[i-1] == ' ' {
+				return s[:i]
+			}
+		}
+	}
+	return s
+}
+
+// next returns the index of the next non empty line.
+func (p *parser) next() int {
+	k := p.i
+	for k < len(p.lines) && p.lines[k].text == "" {
+		k++
+	}
+	return k
+}
+
+// block parses the node starting at the current line, which is indented by at least indent.
+// It returns nil if there is no such node.
+func (p *parser) block(indent int) (*Node, error) {
+	p.i = p.next()
+	if p.i == len(p.lines) {
+		return nil, nil
+	}
+	l := p.lines[p.i]
+	if l.indent < indent {
+		return nil, nil
+	}
+	if isItem(l.text) {
+		return p.sequence(l.indent)
+	}
+	if _, _, ok := splitKey(l.text); ok {
+		return p.mapping(l.indent)
+	}
+	p.i++
+	return inline(l.text, l.num, l.indent+1)
+}
+
+// isItem reports whether s is a sequence item.
+func isItem(s string) bool { return s == "-" || strings.HasPrefix(s, "- ") }
+
+func (p *parser) sequence(indent int) (*Node, error) {
+	l := p.lines[p.i]
+	n := &Node{Kind: Sequence, Line: l.num, Col: indent + 1}
+	for {
+		p.i = p.next()
+		if p.i == len(p.lines) {
+			break
+		}
+		l := p.lines[p.i]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, &Error{l.num, l.indent + 1, "bad indentation of a sequence item"}
+		}
+		if !isItem(l.text) {
+			break
+		}
+		var item *Node
+		rest := strings.TrimLeft(l.text[1:], " ")
+		if rest == "" {
+			p.i++
+			var err error
+			item, err = p.block(indent + 1)
+			if err != nil {
+				return nil, err
+			}
+			if item == nil {
+				item = &Node{Kind: Scalar, Line: l.num, Col: indent + 2}
+			}
+		} else {
+			// Parse the item as if it started on its own line.
+			col := indent + len(l.text) - len(rest)
+			p.lines[p.i] = line{num: l.num, indent: col, text: rest, raw: l.raw}
+			var err error
+			item, err = p.block(col)
+			if err != nil {
+				return nil, err
+			}
+		}
+		n.Items = append(n.Items, item)
+	}
+	return n, nil
+}
+
+func (p *parser) mapping(indent int) (*Node, error) {
+	l := p.lines[p.i]
+	n := &Node{Kind: Mapping, Line: l.num, Col: indent + 1}
+	for {
+		p.i = p.next()
+		if p.i == len(p.lines) {
+			break
+		}
+		l := p.lines[p.i]
+		if l.indent < indent {
+			break
+		}
+		if l.indent > indent {
+			return nil, &Error{l.num, l.indent + 1, "bad indentation of a mapping entry"}
+		}
+		key, rest, ok := splitKey(l.text)
+		if !ok {
+			return nil, &Error{l.num, l.indent + 1, "expected a mapping entry"}
+		}
+		if key == "" {
+			return nil, &Error{l.num, indent + 1, "missing mapping key"}
+		}
+		k, err := scalar(key, l.num, indent+1)
+		if err != nil {
+			return nil, err
+		}
+		if n.Get(k.Value) != nil {
+			return nil, &Error{l.num, indent + 1, fmt.Sprintf("duplicate key %q", k.Value)}
+		}
+		p.i++
+
+		var v *Node
+		col := indent + len(l.text) - len(rest) + 1
+		switch {
+		case rest == "":
+			// The value starts on the next line. Sequences may have the same indentation as the key.
+			if next := p.next(); next < len(p.lines) && p.lines[next].indent == indent && isItem(p.lines[next].text) {
+				p.i = next
+				v, err = p.sequence(indent)
+			} else {
+				v, err = p.block(indent + 1)
+			}
+			if v == nil && err == nil {
+				v = &Node{Kind: Scalar, Line: l.num, Col: col}
+			}
+		case rest[0] == '|' || rest[0] == '>':
+			v, err = p.blockScalar(rest, indent, l.num, col)
+		default:
+			v, err = inline(rest, l.num, col)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.Keys = append(n.Keys, k)
+		n.Values = append(n.Values, v)
+	}
+	return n, nil
+}
+
+// splitKey splits a mapping entry into its key and value.
+func splitKey(s string) (key, value string, ok bool) {
+	if s == "" || strings.IndexByte("[{&*!|>", s[0]) >= 0 || isItem(s) {
+		return "", "", false
+	}
+	i := 0
+	if s[0] == '"' || s[0] == '\'' {
+		// Skip the quoted key.
+		for i = 1; i < len(s) && s[i] != s[0]; i++ {
+			if s[i] == '\\' && s[0] == '"' {
+				i++
+			}
+		}
+	}
+	for ; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ') {
+			return s[:i], strings.TrimLeft(s[i+1:], " "), true
+		}
+	}
+	return "", "", false
+}
+
+// blockScalar parses the literal (|) or folded (>) scalar starting on the next line.
+func (p *parser) blockScalar(header string, indent, num, col int) (*Node, error) {
+	chomp := header[1:]
+	if chomp != "" && chomp != "-" && chomp != "+" {
+		return nil, &Error{num, col + 1, "unsupported block scalar indicator"}
+	}
+	var lines []string
+	content := -1
+	for ; p.i < len(p.lines); p.i++ {
+		l := p.lines[p.i]
+		if strings.TrimSpace(l.raw) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if l.indent <= indent {
+			break
+		}
+		if content < 0 {
+			content = l.indent
+		}
+		if l.indent < content {
+			return nil, &Error{l.num, l.indent + 1, "bad indentation of a block scalar"}
+		}
+		lines = append(lines, l.raw[content:])
+	}
+	// Trailing empty lines are subject to chomping.
+	n := len(lines)
+	for n > 0 && lines[n-1] == "" {
+		n--
+	}
+	var s string
+	if header[0] == '|' {
+		s = strings.Join(lines[:n], "\n")
+	} else {
+		for i, l := range lines[:n] {
+			switch {
+			case l == "":
+				s += "\n"
+			case i > 0 && lines[i-1] != "":
+				s += " " + l
+			default:
+				s += l
+			}
+		}
+	}
+	switch {
+	case chomp == "+":
+		s += strings.Repeat("\n", len(lines)-n+1)
+	case chomp == "" && n > 0:
+		s += "\n"
+	}
+	return &Node{Kind: Scalar, Line: num, Col: col, Value: s, Quoted: true}, nil
+}
+
+// inline parses a value defined on a single line.
+func inline(s string, num, col int) (*Node, error) {
+	switch s[0] {
+	case '&', '*', '!':
+		return nil, &Error{num, col, "anchors, aliases and tags are not supported"}
+	case '{':
+		if strings.TrimSpace(s[1:]) != "}" {
+			return nil, &Error{num, col, "flow mappings are not supported"}
+		}
+		return &Node{Kind: Mapping, Line: num, Col: col}, nil
+	case '[':
+		return flowSequence(s, num, col)
+	}
+	return scalar(s, num, col)
+}
+
+// flowSequence parses a sequence of scalars such as [a, b, c].
+func flowSequence(s string, num, col int) (*Node, error) {
+	if s[len(s)-1] != ']' {
+		return nil, &Error{num, col, "unterminated flow sequence"}
+	}
+	n := &Node{Kind: Sequence, Line: num, Col: col}
+	var quote byte
+	start := 1
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+			continue
+		case c == '"' || c == '\'':
+			quote = c
+			continue
+		case c == '[' || c == '{':
+			return nil, &Error{num, col + i, "nested flow collections are not supported"}
+		case c != ',' && c != ']':
+			continue
+		}
+		raw := strings.TrimRight(s[start:i], " ")
+		item := strings.TrimLeft(raw, " ")
+		icol := col + start + len(raw) - len(item)
+		start = i + 1
+		if item == "" {
+			if c == ']' && len(n.Items) == 0 {
+				break
+			}
+			return nil, &Error{num, col + i, "missing flow sequence item"}
+		}
+		v, err := scalar(item, num, icol)
+		if err != nil {
+			return nil, err
+		}
+		n.Items = append(n.Items, v)
+		if c == ']' && i != len(s)-1 {
+			return nil, &Error{num, col + i + 1, "unexpected content after flow sequence"}
+		}
+	}
+	return n, nil
+}
+
+// scalar parses a plain or quoted scalar.
+func scalar(s string, num, col int) (*Node, error) {
+	n := &Node{Kind: Scalar, Line: num, Col: col}
+	if s == "" {
+		return nil, &Error{num, col, "missing scalar"}
+	}
+	switch s[0] {
+	case '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, &Error{num, col, "invalid double quoted string"}
+		}
+		n.Value, n.Quoted = v, true
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' || strings.Contains(strings.ReplaceAll(s[1:len(s)-1], "''", ""), "'") {
+			return nil, &Error{num, col, "invalid single quoted string"}
+		}
+		n.Value, n.Quoted = strings.ReplaceAll(s[1:len(s)-1], "''", "'"), true
+	case '&', '*', '!':
+		return nil, &Error{num, col, "anchors, aliases and tags are not supported"}
+	case '@', '`':
+		return nil, &Error{num, col, fmt.Sprintf("reserved indicator %q", s[0])}
+	default:
+		if i := strings.Index(s, ": "); i >= 0 {
+			return nil, &Error{num, col + i, "mapping values are not allowed here"}
+		}
+		n.Value = s
+	}
+	return n, nil
+}
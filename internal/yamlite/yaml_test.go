@@ -0,0 +1,93 @@
+package yamlite
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// dump returns a compact representation of n.
+func dump(n *Node) string {
+	switch n.Kind {
+	case Mapping:
+		var s []string
+		for i, k := range n.Keys {
+			s = append(s, k.Value+":"+dump(n.Values[i]))
+		}
+		return "{" + strings.Join(s, " ") + "}"
+	case Sequence:
+		var s []string
+		for _, item := range n.Items {
+			s = append(s, dump(item))
+		}
+		return "[" + strings.Join(s, " ") + "]"
+	}
+	if n.Quoted {
+		return fmt.Sprintf("%q", n.Value)
+	}
+	return n.Value
+}
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		label, in, out string
+	}{
+		{"empty", "", ""},
+		{"scalar", "abc # comment", "abc"},
+		{"mapping", "a: 1\nb: two # comment\nc:\n", "{a:1 b:two c:}"},
+		{"nested", "---\na:\n  b: 1\n  c:\n    d: x\ne: y\n", "{a:{b:1 c:{d:x}} e:y}"},
+		{"sequence", "- a\n-  b\n-\n  c\n", "[a b c]"},
+		{"sequence of mappings", "seq:\n  - id: a\n    type: b4\n  - id: b\n", "{seq:[{id:a type:b4} {id:b}]}"},
+		{"sequence same indent", "seq:\n- id: a\n- id: b\nmeta: x\n", "{seq:[{id:a} {id:b}] meta:x}"},
+		{"nested sequence", "- - a\n  - b\n- c\n", "[[a b] c]"},
+		{"quoted", `a: "x: #y\t"` + "\nb: 'it''s'\n\"c d\": e", `{a:"x: #y\t" b:"it's" c d:e}`},
+		{"flow sequence", "a: [1, 'b', \"c\"]\nb: []\nc: {}\n", `{a:[1 "b" "c"] b:[] c:{}}`},
+		{"literal", "a: |\n  x\n   y\n\nb: |-\n  z\n", `{a:"x\n y\n" b:"z"}`},
+		{"folded", "a: >\n  x\n  y\n\n  z\nb: 1", `{a:"x y\nz\n" b:1}`},
+		{"document end", "a: 1\n...\nb: 2\n", "{a:1}"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			n, err := Parse([]byte(tc.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := dump(n), tc.out; got != want {
+				t.Fatalf("got %s; want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		label, in, err string
+	}{
+		{"tab", "a:\n\tb: 1", "2:1: tabs cannot be used for indentation"},
+		{"indentation", "a: 1\n  b: 2", "2:3: bad indentation of a mapping entry"},
+		{"sequence indentation", "- a\n  - b", "2:3: bad indentation of a sequence item"},
+		{"duplicate", "a: 1\na: 2", `2:1: duplicate key "a"`},
+		{"not an entry", "a: 1\nb", "2:1: expected a mapping entry"},
+		{"anchor", "a: &x 1", "1:4: anchors, aliases and tags are not supported"},
+		{"flow mapping", "a:\n  b: {c: 1}", "2:6: flow mappings are not supported"},
+		{"nested flow", "a: [1, [2]]", "1:8: nested flow collections are not supported"},
+		{"unterminated", "a: [1, 2", "1:4: unterminated flow sequence"},
+		{"value", "a: b: c", "1:5: mapping values are not allowed here"},
+		{"quote", `a: "x`, "1:4: invalid double quoted string"},
+		{"documents", "a: 1\n---\nb: 2", "2:1: multiple documents are not supported"},
+		{"content", "- a\nb: 1", "2:1: unexpected content"},
+		{"empty key", ": x", "1:1: missing mapping key"},
+		{"empty key without value", ": ", "1:1: missing mapping key"},
+		{"empty key in sequence", "- : x", "1:3: missing mapping key"},
+		{"empty key in mapping", "a:\n  b: 1\n  : 2", "3:3: missing mapping key"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			_, err := Parse([]byte(tc.in))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if got, want := err.Error(), tc.err; got != want {
+				t.Fatalf("got %q; want %q", got, want)
+			}
+		})
+	}
+}
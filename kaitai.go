@@ -0,0 +1,371 @@
+package packer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/pierrec/packer/internal/yamlite"
+)
+
+// GenKaitai generates the Kaitai Struct description (.ksy) of the struct s packed as defined by GenPackedStruct.
+//
+// The packed value is described as a seq of bit sized integers (bX) covering all its bits,
+// including the reserved and unused ones, in the order they are read from its bytes:
+// starting with the least significant bits if config.ByteOrder is little endian (bit-endian: le),
+// or with the most significant bits otherwise (bit-endian: be).
+// The Go names and types of the fields are kept in the -orig-id and -go-type keys
+// so that the layout can be read back with LayoutFromKaitai.
+func GenKaitai(w io.Writer, config *Config, s interface{}) error {
+	l, err := config.LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+
+	config.init()
+
+	type _Field struct {
+		ID     string
+		Bits   int
+		OrigID string
+		GoType string
+	}
+	fields := append([]Field(nil), l.Fields...)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Offset < fields[j].Offset })
+	var seq []_Field
+	unused := func(offset, bits int) {
+		seq = append(seq, _Field{ID: fmt.Sprintf("unused_%d", offset), Bits: bits, OrigID: "_"})
+	}
+	var offset int
+	for _, f := range fields {
+		if f.Offset > offset {
+			unused(offset, f.Offset-offset)
+		}
+		field := _Field{ID: snakeCase(f.Name), Bits: f.Bits, OrigID: f.Name, GoType: f.Kind.String()}
+		if f.Reserved() {
+			field.ID = fmt.Sprintf("reserved_%d", f.Offset)
+			field.GoType = ""
+		}
+		seq = append(seq, field)
+		offset = f.Offset + f.Bits
+	}
+	if offset < l.Size {
+		unused(offset, l.Size-offset)
+	}
+	bitEndian := "le"
+	if config.ByteOrder != binary.LittleEndian {
+		// The most significant bits are read first.
+		bitEndian = "be"
+		for i, j := 0, len(seq)-1; i < j; i, j = i+1, j-1 {
+			seq[i], seq[j] = seq[j], seq[i]
+		}
+	}
+
+	err = ksyTemplate.Execute(w, struct {
+		Header    string
+		Comments  string
+		ID        string
+		TypeName  string
+		BitEndian string
+		Fields    []_Field
+	}{
+		lineComments(config.TopComments, "#"),
+		lineComments(layoutComments(l), "#"),
+		snakeCase(l.Name),
+		l.Name,
+		bitEndian,
+		seq,
+	})
+	if err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+var ksyTemplate = template.Must(template.New("kaitai struct gen").Parse(ksySource))
+
+const ksySource = `{{.Header}}
+{{.Comments -}}
+meta:
+  id: {{.ID}}
+  -orig-id: {{.TypeName}}
+  bit-endian: {{.BitEndian}}
+seq:
+{{- range .Fields}}
+  - id: {{.ID}}
+    type: b{{.Bits}}
+    -orig-id: {{.OrigID}}
+{{- if .GoType}}
+    -go-type: {{.GoType}}
+{{- end}}
+{{- end}}
+`
+
+// LayoutFromKaitai returns the layout described by the Kaitai Struct definition read from r.
+//
+// Only the bit sized integers subset of Kaitai Struct is supported: the top level seq must
+// only contain bX fields, which are packed in order. With bit-endian: be, the first field is
+// packed in the most significant bits and the packed value is meant to be read in big endian.
+// Types and fields are named after their -orig-id key, or their id in camel case.
+// Fields have the type set by their -go-type key, or bool for b1 and the smallest unsigned
+// integer type holding their bits otherwise.
+//
+// Invalid or unsupported definitions are reported with a SourceError.
+func LayoutFromKaitai(r io.Reader) (*Layout, error) {
+	root, err := parseYAML(r)
+	if err != nil {
+		return nil, fmt.Errorf("packer: kaitai: %w", err)
+	}
+	l, err := kaitaiLayout(root)
+	if err != nil {
+		return nil, fmt.Errorf("packer: kaitai: %w", err)
+	}
+	return l, nil
+}
+
+// parseYAML parses the YAML document read from r.
+func parseYAML(r io.Reader) (*yamlite.Node, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	root, err := yamlite.Parse(data)
+	if e, ok := err.(*yamlite.Error); ok {
+		return nil, &SourceError{Line: e.Line, Col: e.Col, Err: fmt.Errorf("%w: %s", ErrSyntax, e.Msg)}
+	}
+	return root, err
+}
+
+// nodeError returns the error located at the node n.
+func nodeError(n *yamlite.Node, err error, detail string) error {
+	return &SourceError{Line: n.Line, Col: n.Col, Err: fmt.Errorf("%w: %s", err, detail)}
+}
+
+var (
+	// ksyID matches valid Kaitai Struct identifiers.
+	ksyID = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	// ksyBits matches the bit sized integer types.
+	ksyBits = regexp.MustCompile(`^b([0-9]+)(le|be)?$`)
+)
+
+// ksyMetaKeys lists the supported keys of the meta section, with the ones
+// that must hold a scalar.
+var ksyMetaKeys = map[string]bool{
+	"id": true, "bit-endian": true, "endian": true, "title": true, "application": false,
+	"file-extension": false, "xref": false, "license": true, "ks-version": true,
+	"ks-debug": true, "ks-opaque-types": true, "encoding": true, "tags": false,
+}
+
+func kaitaiLayout(root *yamlite.Node) (*Layout, error) {
+	if root.Kind != yamlite.Mapping {
+		return nil, nodeError(root, ErrInvalidValue, "definition must be a mapping")
+	}
+	for i, k := range root.Keys {
+		switch {
+		case k.Value == "meta", k.Value == "seq", k.Value == "doc", k.Value == "doc-ref":
+		case strings.HasPrefix(k.Value, "-"):
+		default:
+			return nil, nodeError(k, ErrUnsupported, fmt.Sprintf("key %q", k.Value))
+		}
+		if k.Value == "doc" && root.Values[i].Kind != yamlite.Scalar {
+			return nil, nodeError(root.Values[i], ErrInvalidValue, "doc must be a string")
+		}
+	}
+
+	// Type name and bit order.
+	meta := root.Get("meta")
+	if meta == nil {
+		return nil, nodeError(root, ErrInvalidValue, "missing meta")
+	}
+	if meta.Kind != yamlite.Mapping {
+		return nil, nodeError(meta, ErrInvalidValue, "meta must be a mapping")
+	}
+	var id, name string
+	bitEndian := "be"
+	for i, k := range meta.Keys {
+		v := meta.Values[i]
+		scalar, ok := ksyMetaKeys[k.Value]
+		switch {
+		case strings.HasPrefix(k.Value, "-"):
+		case !ok:
+			return nil, nodeError(k, ErrUnsupported, fmt.Sprintf("meta key %q", k.Value))
+		case scalar && v.Kind != yamlite.Scalar:
+			return nil, nodeError(v, ErrUnsupported, fmt.Sprintf("meta %s must be a scalar", k.Value))
+		}
+		switch k.Value {
+		case "id":
+			if !ksyID.MatchString(v.Value) {
+				return nil, nodeError(v, ErrInvalidValue, fmt.Sprintf("id %q", v.Value))
+			}
+			id = v.Value
+		case "-orig-id":
+			if v.Kind != yamlite.Scalar || !token.IsIdentifier(v.Value) {
+				return nil, nodeError(v, ErrInvalidValue, "-orig-id must be a Go identifier")
+			}
+			name = v.Value
+		case "bit-endian", "endian":
+			if v.Value != "le" && v.Value != "be" {
+				return nil, nodeError(v, ErrInvalidValue, fmt.Sprintf("%s %q", k.Value, v.Value))
+			}
+			if k.Value == "bit-endian" {
+				bitEndian = v.Value
+			}
+		}
+	}
+	if id == "" {
+		return nil, nodeError(meta, ErrInvalidValue, "missing meta id")
+	}
+	if name == "" {
+		name = camelCase(id)
+	}
+
+	// Fields.
+	seq := root.Get("seq")
+	if seq == nil {
+		return nil, nodeError(root, ErrEmptyStruct, "missing seq")
+	}
+	if seq.Kind != yamlite.Sequence {
+		return nil, nodeError(seq, ErrInvalidValue, "seq must be a sequence")
+	}
+	if len(seq.Items) == 0 {
+		return nil, nodeError(seq, ErrEmptyStruct, "empty seq")
+	}
+	var fields []Field
+	var size int
+	ids := map[string]bool{}
+	names := map[string]bool{}
+	for _, item := range seq.Items {
+		f, err := kaitaiField(item, bitEndian)
+		if err != nil {
+			return nil, err
+		}
+		fid := item.Get("id").Value
+		if ids[fid] {
+			return nil, nodeError(item.Get("id"), ErrInvalidValue, fmt.Sprintf("duplicate id %q", fid))
+		}
+		ids[fid] = true
+		if names[f.Name] && !f.Reserved() {
+			return nil, nodeError(item, ErrInvalidValue, fmt.Sprintf("duplicate field name %q", f.Name))
+		}
+		names[f.Name] = true
+		if size += f.Bits; size > 64 {
+			return nil, nodeError(item, ErrStructOverflow, fmt.Sprintf("%d bits", size))
+		}
+		fields = append(fields, f)
+	}
+
+	l, err := NewLayout(name, fields)
+	if err != nil {
+		return nil, err
+	}
+	if bitEndian == "be" {
+		l = l.reverse()
+	}
+	return l, nil
+}
+
+// kaitaiField returns the field defined by a seq item.
+func kaitaiField(item *yamlite.Node, bitEndian string) (Field, error) {
+	var f Field
+	if item.Kind != yamlite.Mapping {
+		return f, nodeError(item, ErrInvalidValue, "seq item must be a mapping")
+	}
+	for i, k := range item.Keys {
+		switch k.Value {
+		case "id", "type", "doc", "doc-ref", "-orig-id", "-go-type":
+			if v := item.Values[i]; v.Kind != yamlite.Scalar {
+				return f, nodeError(v, ErrInvalidValue, fmt.Sprintf("%s must be a scalar", k.Value))
+			}
+		default:
+			if !strings.HasPrefix(k.Value, "-") {
+				return f, nodeError(k, ErrUnsupported, fmt.Sprintf("seq key %q", k.Value))
+			}
+		}
+	}
+
+	id := item.Get("id")
+	if id == nil {
+		return f, nodeError(item, ErrInvalidValue, "missing id")
+	}
+	if !ksyID.MatchString(id.Value) {
+		return f, nodeError(id, ErrInvalidValue, fmt.Sprintf("id %q", id.Value))
+	}
+	f.Name = camelCase(id.Value)
+	if n := item.Get("-orig-id"); n != nil {
+		if n.Value != "_" && !token.IsIdentifier(n.Value) {
+			return f, nodeError(n, ErrInvalidValue, "-orig-id must be a Go identifier")
+		}
+		f.Name = n.Value
+	}
+
+	typ := item.Get("type")
+	if typ == nil {
+		return f, nodeError(item, ErrUnsupported, "field without type")
+	}
+	m := ksyBits.FindStringSubmatch(typ.Value)
+	if m == nil {
+		return f, nodeError(typ, ErrUnsupported, fmt.Sprintf("type %q: only bit sized integers are supported", typ.Value))
+	}
+	if m[2] != "" && m[2] != bitEndian {
+		return f, nodeError(typ, ErrUnsupported, fmt.Sprintf("type %q: mixed bit endianness", typ.Value))
+	}
+	f.Bits, _ = strconv.Atoi(m[1])
+	if f.Bits == 0 || f.Bits > 64 {
+		return f, nodeError(typ, ErrInvalidValue, fmt.Sprintf("type %q", typ.Value))
+	}
+
+	switch {
+	case item.Get("-go-type") != nil:
+		n := item.Get("-go-type")
+		f.Kind = kindOfName(n.Value)
+		if f.Kind == reflect.Invalid {
+			return f, nodeError(n, ErrFieldType, n.Value)
+		}
+	default:
+		f.Kind = defaultKind(f.Bits)
+	}
+	if f.Bits > kindBits(f.Kind) {
+		return f, nodeError(typ, ErrFieldOverflow, fmt.Sprintf("%d bits for %s", f.Bits, f.Kind))
+	}
+	f.Type = f.Kind.String()
+	return f, nil
+}
+
+// kindOfName returns the kind of the named basic type supported in packed structs,
+// or reflect.Invalid.
+func kindOfName(name string) reflect.Kind {
+	for k := reflect.Bool; k <= reflect.Uint64; k++ {
+		if k.String() == name && kindBits(k) > 0 {
+			return k
+		}
+	}
+	return reflect.Invalid
+}
+
+// camelCase converts a snake case identifier into an exported Go one: type_of_service becomes TypeOfService.
+func camelCase(s string) string {
+	buf := new(strings.Builder)
+	upper := true
+	for _, c := range s {
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			buf.WriteRune(unicode.ToUpper(c))
+			upper = false
+		default:
+			buf.WriteRune(c)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,150 @@
+package packer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenKaitai(t *testing.T) {
+	for _, s := range []interface{}{Ints{}, Version2{}, Version3{}} {
+		config := goldenConfig()
+		if _, ok := s.(Version2); ok {
+			config.ByteOrder = binary.BigEndian
+		}
+		buf := new(bytes.Buffer)
+		if err := GenKaitai(buf, config, s); err != nil {
+			t.Fatal(err)
+		}
+		name := reflect.TypeOf(s).Name()
+		checkGolden(t, name+".ksy", buf.Bytes())
+
+		// Read the layout back.
+		want, err := config.LayoutOf(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := LayoutFromKaitai(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Name != name || got.Size != want.Size || got.Used() != want.Size {
+			t.Fatalf("got %s of %d bits; want %s of %d bits", got.Name, got.Size, name, want.Size)
+		}
+		for _, wf := range want.Fields {
+			if wf.Reserved() {
+				continue
+			}
+			gf, ok := got.Field(wf.Name)
+			if !ok {
+				t.Fatalf("field %s not found", wf.Name)
+			}
+			if gf.Kind != wf.Kind || gf.Offset != wf.Offset || gf.Bits != wf.Bits {
+				t.Fatalf("%s: got %v %d %d; want %v %d %d",
+					wf.Name, gf.Kind, gf.Offset, gf.Bits, wf.Kind, wf.Offset, wf.Bits)
+			}
+		}
+	}
+}
+
+func TestLayoutFromKaitai(t *testing.T) {
+	const ksy = `meta:
+  id: ipv4_header
+  endian: be
+  bit-endian: be
+  title: IPv4 header start
+seq:
+  - id: version
+    type: b4
+  - id: ihl
+    type: b4
+    doc: Internet header length.
+  - id: dscp
+    type: b6be
+  - id: ecn
+    type: b2
+    -go-type: int8
+  - id: total_length
+    type: b16
+  - id: dont_fragment
+    type: b1
+  - id: flag
+    type: b1
+    -orig-id: _
+`
+	l, err := LayoutFromKaitai(strings.NewReader(ksy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Name != "Ipv4Header" || l.Size != 64 || !l.MSBFirst {
+		t.Fatalf("got %s %d %v; want Ipv4Header 64 true", l.Name, l.Size, l.MSBFirst)
+	}
+	for _, want := range []Field{
+		{Name: "Version", Type: "uint8", Kind: reflect.Uint8, Offset: 60, Bits: 4},
+		{Name: "Ihl", Type: "uint8", Kind: reflect.Uint8, Offset: 56, Bits: 4},
+		{Name: "Dscp", Type: "uint8", Kind: reflect.Uint8, Offset: 50, Bits: 6},
+		{Name: "Ecn", Type: "int8", Kind: reflect.Int8, Offset: 48, Bits: 2},
+		{Name: "TotalLength", Type: "uint16", Kind: reflect.Uint16, Offset: 32, Bits: 16},
+		{Name: "DontFragment", Type: "bool", Kind: reflect.Bool, Offset: 31, Bits: 1},
+	} {
+		got, _ := l.Field(want.Name)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %+v; want %+v", got, want)
+		}
+	}
+	if f := l.Fields[len(l.Fields)-1]; !f.Reserved() {
+		t.Fatalf("got field %s; want reserved", f.Name)
+	}
+}
+
+func TestLayoutFromKaitaiError(t *testing.T) {
+	const meta = "meta:\n  id: x\n"
+	for _, tc := range []struct {
+		label string
+		in    string
+		err   error
+		msg   string
+	}{
+		{"syntax", "meta:\n\tid: x", ErrSyntax,
+			"2:1: syntax error: tabs cannot be used for indentation"},
+		{"types", meta + "types:\n  y: {}\n", ErrUnsupported,
+			`3:1: unsupported construct: key "types"`},
+		{"imports", "meta:\n  id: x\n  imports:\n    - y\n", ErrUnsupported,
+			`3:3: unsupported construct: meta key "imports"`},
+		{"bad id", "meta:\n  id: X\n", ErrInvalidValue,
+			`2:7: invalid value: id "X"`},
+		{"no seq", meta, ErrEmptyStruct,
+			"1:1: empty struct: missing seq"},
+		{"byte type", meta + "seq:\n  - id: a\n    type: u1\n", ErrUnsupported,
+			`5:11: unsupported construct: type "u1": only bit sized integers are supported`},
+		{"repeat", meta + "seq:\n  - id: a\n    type: b1\n    repeat: eos\n", ErrUnsupported,
+			`6:5: unsupported construct: seq key "repeat"`},
+		{"mixed", meta + "seq:\n  - id: a\n    type: b1le\n", ErrUnsupported,
+			`5:11: unsupported construct: type "b1le": mixed bit endianness`},
+		{"go type", meta + "seq:\n  - id: a\n    type: b1\n    -go-type: float32\n", ErrFieldType,
+			"6:15: unsupported field type: float32"},
+		{"field overflow", meta + "seq:\n  - id: a\n    type: b9\n    -go-type: uint8\n", ErrFieldOverflow,
+			"5:11: too many bits for field type: 9 bits for uint8"},
+		{"struct overflow", meta + "seq:\n  - id: a\n    type: b60\n  - id: b\n    type: b5\n", ErrStructOverflow,
+			"6:5: struct overflows uint64: 65 bits"},
+		{"duplicate", meta + "seq:\n  - id: a\n    type: b1\n  - id: a\n    type: b1\n", ErrInvalidValue,
+			`6:9: invalid value: duplicate id "a"`},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			_, err := LayoutFromKaitai(strings.NewReader(tc.in))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got %v; want %v", err, tc.err)
+			}
+			var serr *SourceError
+			if !errors.As(err, &serr) {
+				t.Fatalf("%v: not a SourceError", err)
+			}
+			if got, want := serr.Error(), tc.msg; got != want {
+				t.Fatalf("got %q; want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package packer
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Layout describes how the fields of a struct are packed into an unsigned integer.
+type Layout struct {
+	Name      string  // type name
+	Size      int     // number of bits of the underlying type: 8, 16, 32 or 64
+	MSBFirst  bool    // fields are packed starting with the most significant bits
+	Reordered bool    // fields are not in their declaration order, see Config.Reorder
+	Fields    []Field // fields in declaration order unless Reordered, including reserved ones
+}
+
+// Field describes a packed field.
+type Field struct {
+	Name    string       // method name, _ for reserved bits
+	Type    string       // type returned by the getter
+	PkgPath string       // import path of Type if it is a named type defined in another package
+	Kind    reflect.Kind // kind of the type returned by the getter
+	Offset  int          // position of the field least significant bit
+	Bits    int          // number of bits
+
+	Fixed   bool              // the field is packed at Offset instead of after the previous field
+	Default string            // value set by migrations when the field is new, as a Go literal
+	Enum    []EnumValue       // named values of the field, generated as constants
+	Tag     reflect.StructTag // struct tag: its packer key holds a comma-separated list of key=value options
+}
+
+// EnumValue is a named value of a field.
+type EnumValue struct {
+	Name  string
+	Value int64
+}
+
+// Reserved reports whether the field only reserves bits.
+func (f Field) Reserved() bool { return f.Name == "_" }
+
+// Signed reports whether the field holds a signed integer.
+func (f Field) Signed() bool {
+	switch f.Kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+// signExtended reports whether the getter of the field sign extends its value:
+// the conversion to the returned type sign extends full width values only.
+func (f Field) signExtended() bool {
+	if !f.Signed() {
+		return false
+	}
+	n := kindBits(f.Kind)
+	if f.Kind == reflect.Int {
+		n = 64
+	}
+	return f.Bits == n
+}
+
+// decode returns the value of the field once v is stored in it.
+func (f Field) decode(v uint64) uint64 {
+	v &= uint64(1)<<f.Bits - 1
+	if !f.signExtended() || f.Bits == 64 {
+		return v
+	}
+	n := f.Bits
+	return uint64(int64(v<<(64-n)) >> (64 - n))
+}
+
+// parseTag applies the packer options found in the field tag.
+func (f *Field) parseTag() error {
+	tag, ok := f.Tag.Lookup("packer")
+	if !ok {
+		return nil
+	}
+	for _, opt := range strings.Split(tag, ",") {
+		i := strings.Index(opt, "=")
+		if i < 0 {
+			return ErrFieldTag
+		}
+		switch key, value := opt[:i], opt[i+1:]; key {
+		case "default":
+			f.Default = value
+		case "offset":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 || n > 63 {
+				return ErrFieldTag
+			}
+			f.Offset, f.Fixed = n, true
+		default:
+			return ErrFieldTag
+		}
+	}
+	return nil
+}
+
+// checkDefault makes sure that the default value is valid for the field.
+func (f Field) checkDefault() error {
+	if f.Default == "" {
+		return nil
+	}
+	if f.Kind == reflect.Bool {
+		if f.Default != "true" && f.Default != "false" {
+			return ErrFieldDefault
+		}
+		return nil
+	}
+	var v uint64
+	if f.Signed() {
+		x, err := strconv.ParseInt(f.Default, 0, 64)
+		if err != nil {
+			return ErrFieldDefault
+		}
+		v = uint64(x)
+	} else {
+		x, err := strconv.ParseUint(f.Default, 0, 64)
+		if err != nil {
+			return ErrFieldDefault
+		}
+		v = x
+	}
+	if f.decode(v) != v {
+		return ErrFieldDefault
+	}
+	return nil
+}
+
+// checkEnum makes sure that the enum values can be stored in the field.
+func (f Field) checkEnum() error {
+	if len(f.Enum) > 0 && f.Kind == reflect.Bool {
+		return ErrFieldEnum
+	}
+	for _, e := range f.Enum {
+		if v := uint64(e.Value); f.decode(v) != v || !f.Signed() && e.Value < 0 {
+			return ErrFieldEnum
+		}
+	}
+	return nil
+}
+
+// Mask returns the bits used by the field.
+func (f Field) Mask() uint64 { return (uint64(1)<<f.Bits - 1) << f.Offset }
+
+// Used returns the number of bits used by the fields.
+func (l *Layout) Used() (n int) {
+	for _, f := range l.Fields {
+		end := f.Offset + f.Bits
+		if l.MSBFirst {
+			end = l.Size - f.Offset
+		}
+		if end > n {
+			n = end
+		}
+	}
+	return
+}
+
+// reverse returns a copy of l with the fields packed starting with the most significant bits.
+func (l *Layout) reverse() *Layout {
+	r := *l
+	r.MSBFirst = true
+	r.Fields = make([]Field, len(l.Fields))
+	for i, f := range l.Fields {
+		f.Offset = l.Size - f.Offset - f.Bits
+		r.Fields[i] = f
+	}
+	return &r
+}
+
+// Field returns the field with the given name, if any.
+func (l *Layout) Field(name string) (Field, bool) {
+	for _, f := range l.Fields {
+		if !f.Reserved() && f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// kindBits returns the maximum number of bits a field of the given kind can hold,
+// or 0 if the kind is not supported.
+func kindBits(k reflect.Kind) int {
+	switch k {
+	case reflect.Bool:
+		return 1
+	case reflect.Int, reflect.Uint:
+		// Code generated on 64bits platforms must work on 32bits ones.
+		return 32
+	case reflect.Int8, reflect.Uint8:
+		return 8
+	case reflect.Int16, reflect.Uint16:
+		return 16
+	case reflect.Int32, reflect.Uint32:
+		return 32
+	case reflect.Int64, reflect.Uint64:
+		return 64
+	}
+	return 0
+}
+
+// defaultKind returns the kind of the fields of the given size when their type is not specified:
+// bool for a single bit and the smallest unsigned integer holding the bits otherwise.
+func defaultKind(bits int) reflect.Kind {
+	switch {
+	case bits == 1:
+		return reflect.Bool
+	case bits <= 8:
+		return reflect.Uint8
+	case bits <= 16:
+		return reflect.Uint16
+	case bits <= 32:
+		return reflect.Uint32
+	}
+	return reflect.Uint64
+}
+
+// LayoutOf returns the layout of s as defined by GenPackedStruct.
+func LayoutOf(s interface{}) (*Layout, error) {
+	werrf := func(f string, err error) error { return fmt.Errorf("packer: type %T.%s: %w", s, f, err) }
+
+	if l, ok := s.(*Layout); ok {
+		return l, nil
+	}
+
+	typ := reflect.TypeOf(s)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("packer: type %T: %w", s, ErrNotAStruct)
+	}
+
+	var fields []Field
+	for i, n := 0, typ.NumField(); i < n; i++ {
+		field := typ.Field(i)
+		if field.Anonymous {
+			return nil, werrf(field.Name, ErrEmbeddedField)
+		}
+
+		out := field.Type
+		var bits int
+		switch out.Kind() {
+		case reflect.Bool:
+			bits = 1
+		case reflect.Int8, reflect.Int16, reflect.Int32,
+			reflect.Uint8, reflect.Uint16, reflect.Uint32:
+			bits = out.Bits()
+		case reflect.Array:
+			out = field.Type.Elem()
+			bits = field.Type.Len()
+		default:
+			return nil, werrf(field.Name, ErrFieldBadType)
+		}
+		var pkgPath string
+		if p := out.PkgPath(); p != typ.PkgPath() {
+			pkgPath = p
+		}
+		fields = append(fields, Field{
+			Name:    field.Name,
+			Type:    out.String(),
+			PkgPath: pkgPath,
+			Kind:    out.Kind(),
+			Bits:    bits,
+			Tag:     field.Tag,
+		})
+	}
+	return NewLayout(typ.Name(), fields)
+}
+
+// NewLayout packs the fields in order, starting with the least significant bits,
+// and returns the resulting layout.
+//
+// The fields Name, Type, Kind and Bits must be set. Fields with Fixed set are packed
+// at their Offset and the following ones are packed after them.
+// The options of the packer key in the field tags are applied, see GenPackedStruct.
+func NewLayout(name string, fields []Field) (*Layout, error) {
+	l, i, err := newLayout(name, fields)
+	switch {
+	case err == nil:
+		return l, nil
+	case i < 0:
+		return nil, fmt.Errorf("packer: type %s: %w", name, err)
+	}
+	return nil, fmt.Errorf("packer: type %s.%s: %w", name, fields[i].Name, err)
+}
+
+// newLayout is NewLayout returning the index of the field causing the error, or -1.
+func newLayout(name string, fields []Field) (*Layout, int, error) {
+	l := &Layout{Name: name, Fields: make([]Field, len(fields))}
+	var size, offset int
+	var used uint64
+	for i, field := range fields {
+		n := kindBits(field.Kind)
+		switch {
+		case n == 0:
+			return nil, i, ErrFieldType
+		case field.Bits > n:
+			// Make sure that the extracted bits fit into the returned type.
+			return nil, i, ErrFieldOverflow
+		}
+		if err := field.parseTag(); err != nil {
+			return nil, i, err
+		}
+		if err := field.checkDefault(); err != nil {
+			return nil, i, err
+		}
+		if err := field.checkEnum(); err != nil {
+			return nil, i, err
+		}
+		if field.Fixed {
+			offset = field.Offset
+		}
+		field.Offset = offset
+		offset += field.Bits
+		if offset > 64 {
+			return nil, -1, ErrStructOverflow
+		}
+		if offset > size {
+			size = offset
+		}
+		if field.Mask()&used != 0 {
+			return nil, i, ErrFieldOverlap
+		}
+		used |= field.Mask()
+		l.Fields[i] = field
+	}
+
+	switch {
+	case size <= 0:
+		return nil, -1, ErrEmptyStruct
+	case size <= 8:
+		l.Size = 8
+	case size <= 16:
+		l.Size = 16
+	case size <= 32:
+		l.Size = 32
+	default:
+		l.Size = 64
+	}
+	return l, -1, nil
+}
+
+// fixed reports whether some fields of l have explicit offsets.
+func (l *Layout) fixed() bool {
+	for _, f := range l.Fields {
+		if f.Fixed {
+			return true
+		}
+	}
+	return false
+}
+
+// reserved returns the bits of l that are reserved or unused.
+func (l *Layout) reserved() uint64 {
+	var used uint64
+	for _, f := range l.Fields {
+		if !f.Reserved() {
+			used |= f.Mask()
+		}
+	}
+	return ^used
+}
@@ -0,0 +1,162 @@
+package packer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// GenLuaDissector generates a Wireshark Lua dissector for the struct s packed as defined by GenPackedStruct.
+//
+// The dissector declares a protocol named after the lower cased type name and a ProtoField
+// per field, including the reserved ones, using the bitmask of the field within the packed value.
+// Reserved fields are keyed reserved-<offset>, which no field name can collide with.
+// The packed value is read using config.ByteOrder and its bits are laid out according to config.MSBFirst.
+// Fields are displayed with the same semantics as the Go getters: signed values are only
+// sign extended if the field uses all the bits of its type.
+//
+// The generated file returns the protocol so that it can be registered by the caller, for instance:
+//  DissectorTable.get("udp.port"):add(1234, require("header"))
+func GenLuaDissector(w io.Writer, config *Config, s interface{}) error {
+	l, err := config.LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+
+	config.init()
+
+	proto := strings.ToLower(l.Name)
+	// The protocol variable is suffixed so that it cannot be a Lua keyword.
+	protoVar := proto + "_proto"
+	literal := func(v uint64) string {
+		if l.Size == 64 {
+			// Lua numbers cannot hold all 64 bits values.
+			return fmt.Sprintf("UInt64.fromhex(%q)", fmt.Sprintf("%X", v))
+		}
+		return fmt.Sprintf("0x%X", v)
+	}
+
+	type _Field struct {
+		Key  string // key in the fields table
+		Ref  string // reference to the field in the fields table
+		Type string // ProtoField constructor
+		Abbr string // filter name
+		Name string // displayed name
+		Base string
+		Mask string
+	}
+	var fields []_Field
+	for _, f := range l.Fields {
+		field := _Field{
+			Key:  f.Name,
+			Type: fmt.Sprintf("uint%d", l.Size),
+			Name: f.Name,
+			Base: "base.DEC",
+			Mask: literal(f.Mask()),
+		}
+		switch {
+		case f.Reserved():
+			field.Key = fmt.Sprintf("reserved-%d", f.Offset)
+			field.Name = "reserved"
+			field.Base = "base.HEX"
+		case f.Kind == reflect.Bool:
+			field.Type = "bool"
+			field.Base = fmt.Sprint(l.Size)
+		case f.Signed() && f.Kind != reflect.Int && f.Bits == kindBits(f.Kind):
+			// Wireshark sign extends masked values: only do it for full width values.
+			field.Type = fmt.Sprintf("int%d", l.Size)
+		}
+		field.Abbr = proto + "." + field.Key
+		if luaKeywords[field.Key] || strings.Contains(field.Key, "-") {
+			field.Ref = fmt.Sprintf("fields[%q]", field.Key)
+			field.Key = fmt.Sprintf("[%q]", field.Key)
+		} else {
+			field.Ref = "fields." + field.Key
+		}
+		fields = append(fields, field)
+	}
+
+	add := "add"
+	if l.Size > 8 && config.ByteOrder == binary.LittleEndian {
+		add = "add_le"
+	}
+	err = luaTemplate.Execute(w, struct {
+		Header    string
+		Comments  string
+		TypeName  string
+		Proto     string
+		ProtoVar  string
+		Size      int
+		ByteOrder string
+		Add       string
+		Fields    []_Field
+	}{
+		lineComments(config.TopComments, "--"),
+		lineComments(layoutComments(l), "--"),
+		l.Name,
+		proto,
+		protoVar,
+		l.Size / 8,
+		byteOrderName(config.ByteOrder),
+		add,
+		fields,
+	})
+	if err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+// lineComments replaces the Go line comments markers in s with prefix.
+func lineComments(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "//") {
+			lines[i] = prefix + line[2:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// luaKeywords lists the Lua keywords that cannot be used as table keys in dotted notation
+// nor as variable names.
+var luaKeywords = map[string]bool{
+	"and": true, "break": true, "do": true, "else": true, "elseif": true, "end": true,
+	"false": true, "for": true, "function": true, "goto": true, "if": true, "in": true,
+	"local": true, "nil": true, "not": true, "or": true, "repeat": true, "return": true,
+	"then": true, "true": true, "until": true, "while": true,
+}
+
+var luaTemplate = template.Must(template.New("lua dissector gen").Parse(luaSource))
+
+const luaSource = `{{.Header}}
+{{.Comments -}}
+local {{.ProtoVar}} = Proto("{{.Proto}}", "{{.TypeName}}")
+
+local fields = {
+{{- range .Fields}}
+  {{.Key}} = ProtoField.{{.Type}}("{{.Abbr}}", "{{.Name}}", {{.Base}}, nil, {{.Mask}}),
+{{- end}}
+}
+{{.ProtoVar}}.fields = fields
+
+-- Decodes {{.TypeName}} values stored in {{.Size}} bytes in {{.ByteOrder}} byte order.
+function {{.ProtoVar}}.dissector(buffer, pinfo, tree)
+  if buffer:len() < {{.Size}} then
+    return 0
+  end
+  pinfo.cols.protocol = {{.ProtoVar}}.name
+  local value = buffer(0, {{.Size}})
+  local subtree = tree:add({{.ProtoVar}}, value)
+{{- range .Fields}}
+  subtree:{{$.Add}}({{.Ref}}, value)
+{{- end}}
+  return {{.Size}}
+end
+
+return {{.ProtoVar}}
+`
@@ -0,0 +1,92 @@
+package packer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenLuaDissector(t *testing.T) {
+	for _, s := range []interface{}{Ints{}, Version2{}, Version3{}} {
+		config := goldenConfig()
+		if _, ok := s.(Version2); ok {
+			config.MSBFirst = true
+			config.ByteOrder = binary.BigEndian
+		}
+		buf := new(bytes.Buffer)
+		if err := GenLuaDissector(buf, config, s); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, reflect.TypeOf(s).Name()+".lua", buf.Bytes())
+	}
+}
+
+// luaStubs defines the Wireshark API used by the dissectors, so that loading one lists the
+// abbreviations of its fields.
+const luaStubs = `Proto = function(name, desc) return {name = name} end
+ProtoField = setmetatable({}, {__index = function(_, _)
+  return function(abbr) return abbr end
+end})
+base = {DEC = 1, HEX = 2}
+UInt64 = {fromhex = function(s) return s end}
+local proto = dofile(arg[1])
+local abbrs = {}
+for _, abbr in pairs(proto.fields) do abbrs[#abbrs + 1] = abbr end
+table.sort(abbrs)
+print(proto.name, table.concat(abbrs, " "))
+`
+
+func TestGenLuaDissectorLoad(t *testing.T) {
+	// The type and field names are Lua keywords and a field has the name of a reserved key.
+	type End struct {
+		Local      bool
+		_          [3]uint
+		reserved_1 [4]uint
+	}
+	buf := new(bytes.Buffer)
+	if err := GenLuaDissector(buf, goldenConfig(), End{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var args []string
+	lua, err := exec.LookPath("lua")
+	if err != nil {
+		lua, err = exec.LookPath("luac")
+		if err != nil {
+			t.Skip("no lua nor luac: the dissector is not loaded")
+		}
+		// luac only parses the dissector.
+		args = []string{"-p"}
+	}
+	dir, err := ioutil.TempDir("", "packer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "end.lua")
+	if err := ioutil.WriteFile(file, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	want := ""
+	if args == nil {
+		stubs := filepath.Join(dir, "stubs.lua")
+		if err := ioutil.WriteFile(stubs, []byte(luaStubs), 0644); err != nil {
+			t.Fatal(err)
+		}
+		args = []string{stubs}
+		want = "end\tend.Local end.reserved-1 end.reserved_1"
+	}
+	out, err := exec.Command(lua, append(args, file)...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v: %s\n%s", err, out, buf.Bytes())
+	}
+	if got := strings.TrimSpace(string(out)); got != want {
+		t.Errorf("got %q; want %q", got, want)
+	}
+}
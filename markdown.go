@@ -0,0 +1,113 @@
+package packer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// GenMarkdown generates the Markdown documentation of the struct s packed as defined by GenPackedStruct.
+//
+// The documentation contains the ASCII bit diagram of the layout, as generated by GenDiagram,
+// followed by a table listing the bit range, mask and type of every field in packing order,
+// with reserved and unused ranges in italics.
+func GenMarkdown(w io.Writer, config *Config, s interface{}) error {
+	l, err := config.LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+
+	config.init()
+
+	type _Row struct {
+		Bits    string
+		Field   string
+		Type    string
+		Mask    string
+		Default string
+	}
+	spans := layoutSpans(l)
+	if !l.MSBFirst {
+		// List the fields in declaration order.
+		for i, j := 0, len(spans)-1; i < j; i, j = i+1, j-1 {
+			spans[i], spans[j] = spans[j], spans[i]
+		}
+	}
+	var rows []_Row
+	var defaults bool
+	for _, s := range spans {
+		mask := fmt.Sprintf("`0x%0*X`", l.Size/4, (uint64(1)<<s.bits()-1)<<s.lo)
+		if s.field == nil || s.field.Reserved() {
+			rows = append(rows, _Row{Bits: "*" + s.bitRange() + "*", Field: "*" + s.label() + "*", Mask: mask})
+			continue
+		}
+		row := _Row{
+			Bits:  s.bitRange(),
+			Field: "`" + s.field.Name + "`",
+			Type:  "`" + s.field.Type + "`",
+			Mask:  mask,
+		}
+		if s.field.Default != "" {
+			row.Default = "`" + s.field.Default + "`"
+			defaults = true
+		}
+		rows = append(rows, row)
+	}
+
+	err = mdTemplate.Execute(w, struct {
+		Header   string
+		TypeName string
+		Type     string
+		Size     int
+		Order    string
+		Diagram  string
+		Defaults bool
+		Rows     []_Row
+	}{
+		htmlComment(config.TopComments),
+		l.Name,
+		fmt.Sprintf("uint%d", l.Size),
+		l.Size / 8,
+		map[bool]string{false: "least", true: "most"}[l.MSBFirst],
+		diagram(l),
+		defaults,
+		rows,
+	})
+	if err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+// htmlComment turns Go line comments into an HTML comment.
+func htmlComment(s string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		lines = append(lines, strings.TrimSpace(strings.TrimPrefix(line, "//")))
+	}
+	if s := strings.TrimSpace(strings.Join(lines, "\n")); s != "" {
+		return "<!-- " + s + " -->\n"
+	}
+	return ""
+}
+
+var mdTemplate = template.Must(template.New("markdown gen").Parse(mdSource))
+
+const mdSource = `{{.Header}}
+# {{.TypeName}}
+
+{{.TypeName}} is packed into an {{.Type}} ({{.Size}} bytes), starting with its {{.Order}} significant bits.
+As in RFCs, bit 0 of the diagram is the most significant bit, whereas the table lists the bit offsets.
+
+` + "```text" + `
+{{.Diagram -}}
+` + "```" + `
+
+| Bits | Field | Type | Mask |{{if .Defaults}} Default |{{end}}
+|------|-------|------|------|{{if .Defaults}}---------|{{end}}
+{{- range .Rows}}
+| {{.Bits}} | {{.Field}} | {{.Type}} | {{.Mask}} |{{if $.Defaults}} {{.Default}} |{{end}}
+{{- end}}
+`
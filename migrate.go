@@ -0,0 +1,134 @@
+package packer
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// GenMigration generates the function converting values of the type generated from the from struct
+// into values of the type generated from the to struct, both as defined by GenPackedStruct.
+//
+// The function is named Migrate<from>To<to> and copies the fields found in both types by name.
+// The fields only found in the target type are set to the value of their default tag option, if any.
+// It returns an error listing the fields whose value does not fit their target field,
+// along with the migrated value.
+//
+// Example:
+//  type Version1 struct {
+//    version [4]uint
+//  }
+//  type Version2 struct {
+//    version [4]uint
+//    Len     [16]int `packer:"default=10"`
+//  }
+// results in the following function:
+//  func MigrateVersion1ToVersion2(x Version1) (Version2, error)
+func GenMigration(w io.Writer, config *Config, from, to interface{}) error {
+	lf, err := config.LayoutOf(from)
+	if err != nil {
+		return err
+	}
+	lt, err := config.LayoutOf(to)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: migration from %s to %s: %w", lf.Name, lt.Name, err) }
+	werrf := func(f string, err error) error {
+		return fmt.Errorf("packer: migration from %s to %s.%s: %w", lf.Name, lt.Name, f, err)
+	}
+
+	config.init()
+
+	type _Field struct {
+		Name    string // method name
+		From    string // source type name, empty if the field is new
+		To      string // target type name
+		Default string // default value for new fields
+		Check   bool   // the value may not fit the target field
+	}
+	var fields []_Field
+	var checked bool
+	for _, f := range lt.Fields {
+		if f.Reserved() {
+			continue
+		}
+		field := _Field{
+			Name: f.Name,
+			To:   strings.TrimPrefix(f.Type, config.PkgName+"."),
+		}
+		src, ok := lf.Field(f.Name)
+		if !ok {
+			if f.Default == "" {
+				// Leave the field zeroed.
+				continue
+			}
+			field.Default = f.Default
+			fields = append(fields, field)
+			continue
+		}
+		if (src.Kind == reflect.Bool) != (f.Kind == reflect.Bool) {
+			return werrf(f.Name, ErrFieldMigration)
+		}
+		field.From = strings.TrimPrefix(src.Type, config.PkgName+".")
+		field.Check = field.From != field.To || f.Bits < src.Bits
+		checked = checked || field.Check
+		fields = append(fields, field)
+	}
+
+	var imports []string
+	if checked {
+		imports = []string{"fmt", "strings"}
+	}
+	if err := genHeader(w, config, imports); err != nil {
+		return werr(err)
+	}
+	err = migrationTemplate.Execute(w, struct {
+		Func    string
+		From    string
+		To      string
+		Fields  []_Field
+		Checked bool
+	}{
+		fmt.Sprintf("Migrate%sTo%s", lf.Name, lt.Name),
+		lf.Name,
+		lt.Name,
+		fields,
+		checked,
+	})
+	if err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+var migrationTemplate = template.Must(template.New("migration code gen").Parse(migrationSource))
+
+const migrationSource = `
+// {{.Func}} converts a {{.From}} value into a {{.To}} one.
+func {{.Func}}(x {{.From}}) ({{.To}}, error) {
+	var y {{.To}}
+{{- if .Checked}}
+	var lost []string
+{{- end}}
+{{- range .Fields}}
+{{- if .Default}}
+	y.{{.Name}}Set({{.Default}})
+{{- else if .Check}}
+	if y.{{.Name}}Set({{.To}}(x.{{.Name}}())); {{.From}}(y.{{.Name}}()) != x.{{.Name}}() {
+		lost = append(lost, "{{.Name}}")
+	}
+{{- else}}
+	y.{{.Name}}Set(x.{{.Name}}())
+{{- end}}
+{{- end}}
+{{- if .Checked}}
+	if len(lost) > 0 {
+		return y, fmt.Errorf("{{.Func}}: data loss on %s", strings.Join(lost, ", "))
+	}
+{{- end}}
+	return y, nil
+}
+`
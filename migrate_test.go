@@ -0,0 +1,67 @@
+package packer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Small is a narrower version of Version2.
+type Small struct {
+	flag bool
+	Len  [8]int
+}
+
+func TestGenMigration(t *testing.T) {
+	type tcase struct {
+		from, to interface{}
+		err      error
+	}
+
+	type (
+		BadTag struct {
+			X [4]uint `packer:"default"`
+		}
+		BadDefault struct {
+			X [4]uint `packer:"default=16"`
+		}
+		BadSigned struct {
+			X [4]int `packer:"default=-1"`
+		}
+		Flag struct {
+			flag [4]uint8
+		}
+	)
+
+	for _, tc := range []tcase{
+		{Version1{}, Version2{}, nil},
+		{Version2{}, Version3{}, nil},
+		{Version3{}, Version2{}, nil},
+		{Version2{}, Small{}, nil},
+		{Version1{}, BadTag{}, ErrFieldTag},
+		{Version1{}, BadDefault{}, ErrFieldDefault},
+		{Version1{}, BadSigned{}, ErrFieldDefault},
+		{Version1{}, Flag{}, ErrFieldMigration},
+		{Version1{}, 0, ErrNotAStruct},
+	} {
+		label := fmt.Sprintf("testpkg/%sTo%s_gen.go", reflect.TypeOf(tc.from).Name(), reflect.TypeOf(tc.to).Name())
+		t.Run(label, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			err := GenMigration(buf, &Config{TopComments: goldenConfig().TopComments, PkgName: "testpkg"}, tc.from, tc.to)
+			switch {
+			case tc.err == nil && err != nil:
+				t.Fatal(err)
+			case tc.err != nil && !errors.Is(err, tc.err):
+				t.Fatalf("got %v; want %v", err, tc.err)
+			}
+			if tc.err != nil {
+				// Do not create invalid files.
+				return
+			}
+
+			checkGenerated(t, label, buf.Bytes())
+		})
+	}
+}
@@ -1,4 +1,5 @@
 // Package packer provides utilities to easily perform serialization:
 //  - code generation for safe operations on optimized data structures
-//  - unsigned integers serialization with packing
+//  - signed and unsigned integers serialization with packing
+//  - float64 time series compression
 package packer
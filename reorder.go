@@ -0,0 +1,138 @@
+package packer
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Reorder defines how the fields of a layout are reordered before being packed, see Config.Reorder.
+type Reorder uint8
+
+// Reordering modes.
+const (
+	// ReorderNone packs the fields in their declaration order.
+	ReorderNone Reorder = iota
+	// ReorderSize keeps the smallest backing type and aligns the fields as ReorderAlign does
+	// if it does not grow the type. Otherwise, the fields holding a multiple of 8 bits are
+	// packed first, which keeps them byte aligned, then the others by decreasing size.
+	ReorderSize
+	// ReorderAlign packs the fields holding a multiple of 8 bits at byte boundaries and
+	// the smaller ones within a byte, reserving the padding bits, even if the backing type grows.
+	ReorderAlign
+)
+
+func (r Reorder) String() string {
+	switch r {
+	case ReorderNone:
+		return "none"
+	case ReorderSize:
+		return "size"
+	case ReorderAlign:
+		return "align"
+	}
+	return fmt.Sprintf("Reorder(%d)", uint8(r))
+}
+
+// reorder returns the layout of l with its fields reordered as defined by mode.
+// Layouts with explicit field offsets cannot be reordered.
+func (l *Layout) reorder(mode Reorder) (*Layout, error) {
+	if mode == ReorderNone || l.Reordered {
+		return l, nil
+	}
+	if l.fixed() {
+		return nil, ErrFieldOffset
+	}
+
+	compact, _, err := newLayout(l.Name, compactOrder(l.Fields))
+	if err != nil {
+		return nil, err
+	}
+	r, _, err := newLayout(l.Name, alignedOrder(l.Fields))
+	switch {
+	case mode == ReorderSize && (err != nil || r.Size > compact.Size):
+		r = compact
+	case err != nil:
+		return nil, err
+	}
+	r.Reordered = true
+	if l.MSBFirst {
+		r = r.reverse()
+	}
+	return r, nil
+}
+
+// bySize returns the fields sorted by decreasing number of bits,
+// the ones holding a multiple of 8 bits first.
+func bySize(fields []Field) []Field {
+	sorted := append([]Field(nil), fields...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		fi, fj := sorted[i], sorted[j]
+		if bi, bj := fi.Bits%8 == 0, fj.Bits%8 == 0; bi != bj {
+			return bi
+		}
+		return fi.Bits > fj.Bits
+	})
+	return sorted
+}
+
+// compactOrder returns the fields in the order used by ReorderSize when aligning them would
+// grow the backing type.
+func compactOrder(fields []Field) []Field {
+	return bySize(fields)
+}
+
+// alignedOrder returns the fields in the order used by ReorderAlign, with the reserved fields
+// padding them.
+//
+// The fields holding a multiple of 8 bits come first. The smaller ones are then packed in the
+// first byte with enough free bits, by decreasing size, and the others start a new byte.
+func alignedOrder(fields []Field) []Field {
+	type slot struct {
+		field  Field
+		offset int
+	}
+	var slots []slot
+	var used []int // number of bits used in each byte, from its least significant bit
+	for _, f := range bySize(fields) {
+		i := len(used)
+		if f.Bits < 8 {
+			for j, n := range used {
+				if n+f.Bits <= 8 {
+					i = j
+					break
+				}
+			}
+		}
+		if i < len(used) {
+			slots = append(slots, slot{f, i*8 + used[i]})
+			used[i] += f.Bits
+			continue
+		}
+		slots = append(slots, slot{f, i * 8})
+		for n := f.Bits; n > 0; n -= 8 {
+			if n > 8 {
+				used = append(used, 8)
+			} else {
+				used = append(used, n)
+			}
+		}
+	}
+
+	sort.SliceStable(slots, func(i, j int) bool { return slots[i].offset < slots[j].offset })
+	var res []Field
+	var offset int
+	for _, s := range slots {
+		if pad := s.offset - offset; pad > 0 {
+			res = append(res, paddingField(pad))
+		}
+		res = append(res, s.field)
+		offset = s.offset + s.field.Bits
+	}
+	return res
+}
+
+// paddingField returns a reserved field of the given number of bits.
+func paddingField(bits int) Field {
+	k := defaultKind(bits)
+	return Field{Name: "_", Type: k.String(), Kind: k, Bits: bits}
+}
@@ -0,0 +1,73 @@
+package packer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestReorder(t *testing.T) {
+	type (
+		Fits struct {
+			a [3]uint8
+			b [6]uint8
+			c [7]uint8
+			d [16]uint16
+		}
+		Small struct {
+			a bool
+			b [12]uint16
+			c [2]uint8
+			d uint8
+		}
+	)
+	// order returns the fields of l as name:offset, padding included.
+	order := func(l *Layout) string {
+		var s []string
+		for _, f := range l.Fields {
+			s = append(s, fmt.Sprintf("%s:%d", f.Name, f.Offset))
+		}
+		return fmt.Sprintf("%d %s", l.Size, strings.Join(s, " "))
+	}
+	for _, tc := range []struct {
+		label string
+		in    interface{}
+		cfg   Config
+		out   string
+	}{
+		{"none", Fits{}, Config{}, "32 a:0 b:3 c:9 d:16"},
+		{"size", Fits{}, Config{Reorder: ReorderSize}, "32 d:0 c:16 b:23 a:29"},
+		{"align", Fits{}, Config{Reorder: ReorderAlign}, "64 d:0 c:16 _:23 b:24 _:30 a:32"},
+		{"size aligned", Small{}, Config{Reorder: ReorderSize}, "32 d:0 b:8 c:20 a:22"},
+		{"align msb", Small{}, Config{Reorder: ReorderAlign, MSBFirst: true}, "32 d:24 b:12 c:10 a:9"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			l, err := tc.cfg.LayoutOf(tc.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := order(l), tc.out; got != want {
+				t.Fatalf("got %s; want %s", got, want)
+			}
+			if l.Reordered != (tc.cfg.Reorder != ReorderNone) {
+				t.Fatalf("got Reordered=%v", l.Reordered)
+			}
+			// Layouts are only reordered once.
+			if l2, _ := tc.cfg.LayoutOf(l); l2.Size != l.Size || len(l2.Fields) != len(l.Fields) {
+				t.Fatalf("got %s; want %s", order(l2), order(l))
+			}
+		})
+	}
+}
+
+func TestReorderFixed(t *testing.T) {
+	type Fixed struct {
+		a [4]uint8
+		b [4]uint8 `packer:"offset=8"`
+	}
+	_, err := (&Config{Reorder: ReorderSize}).LayoutOf(Fixed{})
+	if !errors.Is(err, ErrFieldOffset) {
+		t.Fatalf("got %v; want %v", err, ErrFieldOffset)
+	}
+}
@@ -0,0 +1,173 @@
+package packer
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// GenRust generates the Rust code giving access to the members of the struct s packed as defined by GenPackedStruct.
+//
+// The packed type is a #[repr(transparent)] newtype over u8, u16, u32 or u64.
+// Its fields are read with const fn getters named after the fields in snake case
+// and set with builder-style const fn setters prefixed with with_.
+// It returns an error wrapping ErrNameCollision if two fields result in the same method name.
+//
+// Example:
+//  type Header struct{
+//    version [4]uint
+//    Flag    bool
+//  }
+// results in the following type:
+//  pub struct Header(pub u8);
+// with methods:
+//  pub const fn version(self) -> u64
+//  pub const fn flag(self) -> bool
+//  pub const fn with_version(self, v: u64) -> Self
+//  pub const fn with_flag(self, v: bool) -> Self
+func GenRust(w io.Writer, config *Config, s interface{}) error {
+	l, err := config.LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+
+	config.init()
+
+	type _Field struct {
+		Name   string // getter name
+		Setter string // setter name
+		Out    string // returned Rust type
+		Bool   bool
+		Shift  int
+		Mask   string
+	}
+	var fields []_Field
+	// Field owning each generated method name.
+	methods := map[string]string{}
+	for _, f := range l.Fields {
+		if f.Reserved() {
+			continue
+		}
+		name := snakeCase(f.Name)
+		for _, m := range []string{name, "with_" + name} {
+			if other, ok := methods[m]; ok {
+				return werr(fmt.Errorf("%w: %s and %s both define %s", ErrNameCollision, other, f.Name, m))
+			}
+			methods[m] = f.Name
+		}
+		fields = append(fields, _Field{
+			Name:   rustIdent(name),
+			Setter: "with_" + name,
+			Out:    rustTypes[f.Kind],
+			Bool:   f.Kind == reflect.Bool,
+			Shift:  f.Offset,
+			Mask:   fmt.Sprintf("0x%X", uint64(1)<<f.Bits-1),
+		})
+	}
+
+	err = rustTemplate.Execute(w, struct {
+		Header   string
+		Comments string
+		TypeName string
+		Type     string
+		Reserved string
+		Fields   []_Field
+	}{
+		config.TopComments,
+		layoutComments(l),
+		l.Name,
+		fmt.Sprintf("u%d", l.Size),
+		fmt.Sprintf("0x%X", l.reserved()&(^uint64(0)>>(64-l.Size))),
+		fields,
+	})
+	if err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+// rustTypes maps the kinds of the fields to their Rust type.
+var rustTypes = map[reflect.Kind]string{
+	reflect.Bool:   "bool",
+	reflect.Int:    "i64",
+	reflect.Int8:   "i8",
+	reflect.Int16:  "i16",
+	reflect.Int32:  "i32",
+	reflect.Int64:  "i64",
+	reflect.Uint:   "u64",
+	reflect.Uint8:  "u8",
+	reflect.Uint16: "u16",
+	reflect.Uint32: "u32",
+	reflect.Uint64: "u64",
+}
+
+// snakeCase converts a Go identifier into snake case: TypeOfService becomes type_of_service.
+func snakeCase(s string) string {
+	r := []rune(s)
+	buf := new(strings.Builder)
+	for i, c := range r {
+		if unicode.IsUpper(c) {
+			// Start a new word unless within an acronym.
+			if i > 0 && r[i-1] != '_' &&
+				(unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1]) || i+1 < len(r) && unicode.IsLower(r[i+1])) {
+				buf.WriteByte('_')
+			}
+			c = unicode.ToLower(c)
+		}
+		buf.WriteRune(c)
+	}
+	return buf.String()
+}
+
+// rustKeywords lists the Rust keywords that can be used as raw identifiers.
+var rustKeywords = map[string]bool{
+	"as": true, "async": true, "await": true, "break": true, "const": true, "continue": true,
+	"dyn": true, "else": true, "enum": true, "extern": true, "false": true, "fn": true,
+	"for": true, "if": true, "impl": true, "in": true, "let": true, "loop": true,
+	"match": true, "mod": true, "move": true, "mut": true, "pub": true, "ref": true,
+	"return": true, "static": true, "struct": true, "trait": true, "true": true, "type": true,
+	"unsafe": true, "use": true, "where": true, "while": true, "abstract": true, "become": true,
+	"box": true, "do": true, "final": true, "macro": true, "override": true, "priv": true,
+	"try": true, "typeof": true, "unsized": true, "virtual": true, "yield": true,
+}
+
+// rustIdent escapes Rust keywords.
+func rustIdent(s string) string {
+	if rustKeywords[s] {
+		return "r#" + s
+	}
+	return s
+}
+
+var rustTemplate = template.Must(template.New("rust code gen").Parse(rustSource))
+
+const rustSource = `{{.Header}}
+{{.Comments -}}
+#[repr(transparent)]
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Hash)]
+pub struct {{.TypeName}}(pub {{.Type}});
+
+impl {{.TypeName}} {
+    /// Bits that are reserved or unused.
+    pub const RESERVED_MASK: {{.Type}} = {{.Reserved}};
+{{range .Fields}}
+    pub const fn {{.Name}}(self) -> {{.Out}} {
+{{- if .Bool}}
+        (self.0 >> {{.Shift}}) & {{.Mask}} != 0
+{{- else}}
+        ((self.0 >> {{.Shift}}) & {{.Mask}}) as {{.Out}}
+{{- end}}
+    }
+{{end}}
+{{- range .Fields}}
+    #[must_use]
+    pub const fn {{.Setter}}(self, v: {{.Out}}) -> Self {
+        Self((self.0 & !({{.Mask}} << {{.Shift}})) | (((v as {{$.Type}}) & {{.Mask}}) << {{.Shift}}))
+    }
+{{end -}}
+}
+`
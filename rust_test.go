@@ -0,0 +1,108 @@
+package packer
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSnakeCase(t *testing.T) {
+	for _, tc := range []struct{ in, out string }{
+		{"version", "version"},
+		{"Len", "len"},
+		{"Int8", "int8"},
+		{"TypeOfService", "type_of_service"},
+		{"IHL", "ihl"},
+		{"HTTPStatus", "http_status"},
+		{"my_field", "my_field"},
+	} {
+		if got := snakeCase(tc.in); got != tc.out {
+			t.Errorf("%s: got %q; want %q", tc.in, got, tc.out)
+		}
+	}
+}
+
+func TestGenRustNameCollision(t *testing.T) {
+	for _, tc := range []struct {
+		label string
+		in    interface{}
+		msg   string
+	}{
+		{"same name", struct {
+			Len [4]uint8
+			len [4]uint8
+		}{}, "Len and len both define len"},
+		{"setter name", struct {
+			X     bool
+			WithX bool
+		}{}, "X and WithX both define with_x"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			err := GenRust(new(bytes.Buffer), goldenConfig(), tc.in)
+			if !errors.Is(err, ErrNameCollision) {
+				t.Fatalf("got %v; want %v", err, ErrNameCollision)
+			}
+			if !strings.Contains(err.Error(), tc.msg) {
+				t.Fatalf("got %q; want %q in it", err, tc.msg)
+			}
+		})
+	}
+}
+
+func TestGenRust(t *testing.T) {
+	src := new(bytes.Buffer)
+	for _, s := range []interface{}{Ints{}, Version3{}} {
+		buf := new(bytes.Buffer)
+		if err := GenRust(buf, goldenConfig(), s); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, reflect.TypeOf(s).Name()+".rs", buf.Bytes())
+		src.Write(buf.Bytes())
+	}
+
+	rustc, err := exec.LookPath("rustc")
+	if err != nil {
+		t.Skip("no Rust compiler: only the golden files are checked")
+	}
+	dir, err := ioutil.TempDir("", "packer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	checkLang(t, map[string]string{
+		"ints": `    let i = Ints(0).with_int8(-3).with_int16(-1000).with_int32(-100000);
+    println!("{:x} {} {} {}", i.0, i.int8(), i.int16(), i.int32());`,
+		"version3": `    let v = V3;
+    println!("{:x} {} {} {} {:x}", v.0, v.version(), v.flag() as u8, v.len(), v.checksum());`,
+		"reserved": `    let v = v.with_flag(false);
+    println!("{} {:x}", v.flag() as u8, v.0 & Version3::RESERVED_MASK);`,
+	}, nil, func(body string) []byte {
+		src.WriteString(`
+const V3: Version3 = Version3(0).with_version(9).with_flag(true).with_len(1000).with_checksum(0xDEADBEEF);
+
+fn main() {
+` + body + `
+}
+`)
+		path := filepath.Join(dir, "main.rs")
+		if err := ioutil.WriteFile(path, src.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		bin := filepath.Join(dir, "main")
+		if out, err := exec.Command(rustc, "--edition", "2021", "-A", "dead_code", "-o", bin, path).CombinedOutput(); err != nil {
+			t.Fatalf("%v: %s", err, out)
+		}
+		out, err := exec.Command(bin).Output()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return out
+	})
+}
@@ -0,0 +1,269 @@
+package packer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+
+	"github.com/pierrec/packer/internal/jsonschema"
+	"github.com/pierrec/packer/internal/yamlite"
+)
+
+//go:generate go run schemagen.go
+
+// LayoutSchema is the JSON Schema of the layout definitions read by LayoutFromSchema.
+// The layout.schema.json file of the repository is generated from it.
+const LayoutSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "https://github.com/pierrec/packer/layout.schema.json",
+  "title": "packer layout",
+  "description": "Packed struct layout read by packer.LayoutFromSchema.",
+  "type": "object",
+  "required": ["name", "fields"],
+  "additionalProperties": false,
+  "properties": {
+    "name": {
+      "description": "Go type name.",
+      "type": "string",
+      "pattern": "^[A-Za-z][A-Za-z0-9_]*$"
+    },
+    "doc": {
+      "description": "Documentation of the layout.",
+      "type": "string"
+    },
+    "bitOrder": {
+      "description": "Pack the first field in the least (lsb) or most (msb) significant bits.",
+      "enum": ["lsb", "msb"]
+    },
+    "size": {
+      "description": "Number of bits of the packed type, by default the smallest holding all the fields.",
+      "type": "integer",
+      "enum": [8, 16, 32, 64]
+    },
+    "fields": {
+      "type": "array",
+      "minItems": 1,
+      "items": {"$ref": "#/definitions/field"}
+    }
+  },
+  "definitions": {
+    "field": {
+      "type": "object",
+      "required": ["name", "bits"],
+      "additionalProperties": false,
+      "properties": {
+        "name": {
+          "description": "Go field name, _ for reserved bits.",
+          "type": "string",
+          "pattern": "^([A-Za-z][A-Za-z0-9]*|_)$"
+        },
+        "doc": {
+          "description": "Documentation of the field.",
+          "type": "string"
+        },
+        "bits": {
+          "type": "integer",
+          "minimum": 1,
+          "maximum": 64
+        },
+        "type": {
+          "description": "Go type returned by the field getter, by default the smallest one holding its bits.",
+          "enum": ["bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64"]
+        },
+        "signed": {
+          "description": "Use the signed type of the same size when type is not set, bits being 8, 16, 32 or 64: narrower signed fields do not sign extend their value.",
+          "type": "boolean"
+        },
+        "offset": {
+          "description": "Position of the least significant bit of the field, the following fields being packed after it. Only valid with the lsb bit order.",
+          "type": "integer",
+          "minimum": 0,
+          "maximum": 63
+        },
+        "default": {
+          "description": "Value of the field when migrated from a layout that does not have it, as a Go literal.",
+          "type": ["integer", "boolean", "string"]
+        },
+        "enum": {
+          "description": "Named values of the field, generated as constants.",
+          "type": "object",
+          "propertyNames": {"pattern": "^[A-Z][A-Za-z0-9]*$"},
+          "additionalProperties": {"type": "integer"}
+        }
+      }
+    }
+  }
+}
+`
+
+var layoutSchema = jsonschema.MustCompile(LayoutSchema)
+
+// LayoutFromSchema returns the layout described by the YAML or JSON definition read from r,
+// which must validate against LayoutSchema, such as:
+//  name: Header
+//  bitOrder: msb
+//  fields:
+//    - name: version
+//      bits: 4
+//      enum:
+//        V4: 4
+//        V6: 6
+//    - name: _
+//      bits: 4
+//    - name: length
+//      bits: 24
+//
+// Fields are packed in order, starting with the least significant bits unless bitOrder is msb,
+// or at their offset if set. Their type is set by their type key, or is bool for a single bit
+// and the smallest unsigned integer holding their bits otherwise, or the signed one of the same size
+// if their signed key is true, which requires 8, 16, 32 or 64 bits as narrower signed fields do not
+// sign extend their value.
+// Their enum values are generated as constants, see GenPackedStruct.
+//
+// The document is parsed as JSON if it starts with {, as YAML otherwise.
+// Invalid definitions are reported with a SourceError holding the path of the offending value.
+func LayoutFromSchema(r io.Reader) (*Layout, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var root *yamlite.Node
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		root, err = yamlite.ParseJSON(data)
+	} else {
+		root, err = yamlite.Parse(data)
+	}
+	if e, ok := err.(*yamlite.Error); ok {
+		return nil, fmt.Errorf("packer: schema: %w", &SourceError{Line: e.Line, Col: e.Col, Err: fmt.Errorf("%w: %s", ErrSyntax, e.Msg)})
+	}
+	if err := layoutSchema.Validate(root); err != nil {
+		e := err.(*jsonschema.Error)
+		return nil, fmt.Errorf("packer: schema: %w", &SourceError{Line: e.Line, Col: e.Col, Path: e.Path, Err: fmt.Errorf("%w: %s", ErrInvalidValue, e.Msg)})
+	}
+	l, err := schemaLayout(root)
+	if err != nil {
+		return nil, fmt.Errorf("packer: schema: %w", err)
+	}
+	return l, nil
+}
+
+// signedKinds maps the unsigned kinds to the signed ones of the same size.
+var signedKinds = map[reflect.Kind]reflect.Kind{
+	reflect.Uint8:  reflect.Int8,
+	reflect.Uint16: reflect.Int16,
+	reflect.Uint32: reflect.Int32,
+	reflect.Uint64: reflect.Int64,
+}
+
+// pathError returns the error located at the node n with the given path.
+func pathError(n *yamlite.Node, path string, err error, detail string) error {
+	if detail != "" {
+		err = fmt.Errorf("%w: %s", err, detail)
+	}
+	return &SourceError{Line: n.Line, Col: n.Col, Path: path, Err: err}
+}
+
+// schemaLayout returns the layout of a definition validated against LayoutSchema.
+func schemaLayout(root *yamlite.Node) (*Layout, error) {
+	name := root.Get("name").Value
+	msb := false
+	if n := root.Get("bitOrder"); n != nil {
+		msb = n.Value == "msb"
+	}
+
+	items := root.Get("fields").Items
+	fields := make([]Field, len(items))
+	for i, item := range items {
+		path := fmt.Sprintf("/fields/%d", i)
+		f := &fields[i]
+		f.Name = item.Get("name").Value
+		bits, err := intValue(item.Get("bits"), path+"/bits")
+		if err != nil {
+			return nil, err
+		}
+		f.Bits = int(bits)
+		if n := item.Get("type"); n != nil {
+			f.Kind = kindOfName(n.Value)
+			if n := item.Get("signed"); n != nil {
+				return nil, pathError(n, path+"/signed", ErrInvalidValue, "signed cannot be set with type")
+			}
+		} else {
+			f.Kind = defaultKind(f.Bits)
+			if n := item.Get("signed"); n != nil {
+				signed, err := n.Bool()
+				if err != nil {
+					return nil, pathError(n, path+"/signed", ErrInvalidValue, err.Error())
+				}
+				if signed {
+					k, ok := signedKinds[f.Kind]
+					if !ok || kindBits(k) != f.Bits {
+						return nil, pathError(n, path+"/signed", ErrInvalidValue, "signed fields must use 8, 16, 32 or 64 bits")
+					}
+					f.Kind = k
+				}
+			}
+		}
+		f.Type = f.Kind.String()
+		if n := item.Get("offset"); n != nil {
+			if msb {
+				return nil, pathError(n, path+"/offset", ErrFieldOffset, "offsets require the lsb bit order")
+			}
+			offset, err := intValue(n, path+"/offset")
+			if err != nil {
+				return nil, err
+			}
+			f.Offset, f.Fixed = int(offset), true
+		}
+		if n := item.Get("default"); n != nil {
+			f.Default = n.Value
+		}
+		if n := item.Get("enum"); n != nil {
+			for j, k := range n.Keys {
+				v, err := intValue(n.Values[j], path+"/enum/"+k.Value)
+				if err != nil {
+					return nil, err
+				}
+				f.Enum = append(f.Enum, EnumValue{k.Value, v})
+			}
+		}
+		for j := 0; j < i; j++ {
+			if !f.Reserved() && fields[j].Name == f.Name {
+				n := item.Get("name")
+				return nil, pathError(n, path+"/name", ErrInvalidValue, fmt.Sprintf("duplicate name %q", f.Name))
+			}
+		}
+	}
+
+	l, i, err := newLayout(name, fields)
+	if err != nil {
+		if i < 0 {
+			return nil, pathError(root.Get("fields"), "/fields", err, "")
+		}
+		return nil, pathError(items[i], fmt.Sprintf("/fields/%d", i), err, "")
+	}
+	if n := root.Get("size"); n != nil {
+		size, err := intValue(n, "/size")
+		if err != nil {
+			return nil, err
+		}
+		if int(size) < l.Size {
+			return nil, pathError(n, "/size", ErrStructOverflow, fmt.Sprintf("%d bits", l.Size))
+		}
+		l.Size = int(size)
+	}
+	if msb {
+		l = l.reverse()
+	}
+	return l, nil
+}
+
+// intValue returns the value of the integer node n located at path.
+func intValue(n *yamlite.Node, path string) (int64, error) {
+	x, err := n.Int()
+	if err != nil {
+		return 0, pathError(n, path, ErrInvalidValue, err.Error())
+	}
+	return x, nil
+}
@@ -0,0 +1,166 @@
+package packer
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLayoutSchema(t *testing.T) {
+	data, err := ioutil.ReadFile("layout.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := LayoutSchema, string(data); got != want {
+		t.Fatal("layout.schema.json is stale: run go generate")
+	}
+}
+
+func TestLayoutFromSchema(t *testing.T) {
+	want := &Layout{Name: "Packet", Size: 64, Fields: []Field{
+		{Name: "kind", Type: "uint8", Kind: reflect.Uint8, Offset: 0, Bits: 3,
+			Enum: []EnumValue{{"Data", 0}, {"Ack", 1}, {"Nack", 2}}},
+		{Name: "Urgent", Type: "bool", Kind: reflect.Bool, Offset: 3, Bits: 1},
+		{Name: "Delta", Type: "int16", Kind: reflect.Int16, Offset: 4, Bits: 16},
+		{Name: "Seq", Type: "uint16", Kind: reflect.Uint16, Offset: 32, Bits: 16, Fixed: true, Default: "1"},
+	}}
+	var layouts []*Layout
+	for _, name := range []string{"testdata/Packet.yaml", "testdata/Packet.json"} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		l, err := LayoutFromSchema(f)
+		_ = f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(l, want) {
+			t.Fatalf("%s: got %+v; want %+v", name, l, want)
+		}
+		layouts = append(layouts, l)
+	}
+
+	buf := new(bytes.Buffer)
+	config := goldenConfig()
+	config.PkgName = "testpkg"
+	if err := GenPackedLayout(buf, config, layouts[0]); err != nil {
+		t.Fatal(err)
+	}
+	checkGenerated(t, "testpkg/Packet_gen.go", buf.Bytes())
+}
+
+func TestLayoutFromSchemaMSB(t *testing.T) {
+	const def = "name: Flags\nbitOrder: msb\nsize: 16\nfields:\n" +
+		"  - name: a\n    bits: 1\n" +
+		"  - name: b\n    bits: 8\n    signed: true\n"
+	l, err := LayoutFromSchema(strings.NewReader(def))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !l.MSBFirst || l.Size != 16 {
+		t.Fatalf("got MSBFirst=%v Size=%d; want true 16", l.MSBFirst, l.Size)
+	}
+	if a, b := l.Fields[0], l.Fields[1]; a.Offset != 15 || b.Offset != 7 || b.Kind != reflect.Int8 {
+		t.Fatalf("got %+v %+v", a, b)
+	}
+}
+
+func TestLayoutFromSchemaDoc(t *testing.T) {
+	// The example of the LayoutFromSchema documentation.
+	const def = `name: Header
+bitOrder: msb
+fields:
+  - name: version
+    bits: 4
+    enum:
+      V4: 4
+      V6: 6
+  - name: _
+    bits: 4
+  - name: length
+    bits: 24
+`
+	l, err := LayoutFromSchema(strings.NewReader(def))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []EnumValue{{"V4", 4}, {"V6", 6}}
+	if v := l.Fields[0]; v.Offset != 28 || !reflect.DeepEqual(v.Enum, want) {
+		t.Fatalf("got %+v; want offset 28 and enum %v", v, want)
+	}
+	if n := l.Fields[2]; n.Offset != 0 || n.Bits != 24 {
+		t.Fatalf("got %+v; want offset 0 and 24 bits", n)
+	}
+}
+
+func TestLayoutFromSchemaError(t *testing.T) {
+	const head = "name: X\nfields:\n"
+	for _, tc := range []struct {
+		label string
+		in    string
+		err   error
+		msg   string
+	}{
+		{"yaml syntax", "name: X\n\tfields: 1", ErrSyntax,
+			"2:1: syntax error: tabs cannot be used for indentation"},
+		{"json syntax", `{"name": "X", "fields": [}`, ErrSyntax,
+			"1:26: syntax error: invalid character '}' looking for beginning of value"},
+		{"missing fields", "name: X\n", ErrInvalidValue,
+			`1:1: /: invalid value: missing property "fields"`},
+		{"unknown property", head + "  - name: a\n    bits: 1\n    width: 2\n", ErrInvalidValue,
+			`5:5: /fields/0/width: invalid value: unknown property "width"`},
+		{"bad name", "name: 1X\nfields: []\n", ErrInvalidValue,
+			`1:7: /name: invalid value: "1X" does not match ^[A-Za-z][A-Za-z0-9_]*$`},
+		{"no field", "name: X\nfields: []\n", ErrInvalidValue,
+			"2:9: /fields: invalid value: got 0 items; want at least 1"},
+		{"bits", head + "  - name: a\n    bits: 65\n", ErrInvalidValue,
+			"4:11: /fields/0/bits: invalid value: 65 is greater than 64"},
+		{"type", head + "  - name: a\n    bits: 1\n    type: float32\n", ErrInvalidValue,
+			`5:11: /fields/0/type: invalid value: "float32" is not one of bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64`},
+		{"json type", `{"name": "X", "fields": [{"name": "a", "bits": "1"}]}`, ErrInvalidValue,
+			"1:48: /fields/0/bits: invalid value: got string; want integer"},
+		{"enum name", head + "  - name: a\n    bits: 2\n    enum: {}\n  - name: b\n    bits: 2\n    enum:\n      x: 1\n", ErrInvalidValue,
+			`9:7: /fields/1/enum/x: invalid value: "x" does not match ^[A-Z][A-Za-z0-9]*$`},
+		{"partial signed", head + "  - name: a\n    bits: 12\n    signed: true\n", ErrInvalidValue,
+			"5:13: /fields/0/signed: invalid value: signed fields must use 8, 16, 32 or 64 bits"},
+		{"signed with type", head + "  - name: a\n    bits: 2\n    type: int8\n    signed: true\n", ErrInvalidValue,
+			"6:13: /fields/0/signed: invalid value: signed cannot be set with type"},
+		{"duplicate", head + "  - name: a\n    bits: 2\n  - name: a\n    bits: 2\n", ErrInvalidValue,
+			`5:11: /fields/1/name: invalid value: duplicate name "a"`},
+		{"field overflow", head + "  - name: a\n    bits: 9\n    type: uint8\n", ErrFieldOverflow,
+			"3:5: /fields/0: too many bits for field type"},
+		{"overlap", head + "  - name: a\n    bits: 8\n  - name: b\n    bits: 8\n    offset: 4\n", ErrFieldOverlap,
+			"5:5: /fields/1: field overlaps another one"},
+		{"struct overflow", head + "  - name: a\n    bits: 8\n    offset: 60\n", ErrStructOverflow,
+			"3:3: /fields: struct overflows uint64"},
+		{"msb offset", "bitOrder: msb\n" + head + "  - name: a\n    bits: 8\n    offset: 8\n", ErrFieldOffset,
+			"6:13: /fields/0/offset: explicit field offsets not supported: offsets require the lsb bit order"},
+		{"enum value", head + "  - name: a\n    bits: 2\n    enum:\n      A: 4\n", ErrFieldEnum,
+			"3:5: /fields/0: invalid enum value"},
+		{"enum overflow", head + "  - name: a\n    bits: 2\n    enum:\n      A: 99999999999999999999\n", ErrInvalidValue,
+			`6:10: /fields/0/enum/A: invalid value: strconv.ParseInt: parsing "99999999999999999999": value out of range`},
+		{"default", head + "  - name: a\n    bits: 2\n    default: 4\n", ErrFieldDefault,
+			"3:5: /fields/0: invalid default value"},
+		{"size", "size: 8\n" + head + "  - name: a\n    bits: 9\n", ErrStructOverflow,
+			"1:7: /size: struct overflows uint64: 16 bits"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			_, err := LayoutFromSchema(strings.NewReader(tc.in))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got %v; want %v", err, tc.err)
+			}
+			var serr *SourceError
+			if !errors.As(err, &serr) {
+				t.Fatalf("%v: not a SourceError", err)
+			}
+			if got, want := serr.Error(), tc.msg; got != want {
+				t.Fatalf("got %q; want %q", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+//+build ignore
+
+package main
+
+import (
+	"io/ioutil"
+	"log"
+
+	"github.com/pierrec/packer"
+)
+
+func main() {
+	if err := ioutil.WriteFile("layout.schema.json", []byte(packer.LayoutSchema), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,135 @@
+package packer
+
+import "github.com/pierrec/packer/internal/packuint"
+
+// SeqMode defines how the values of a sequence are encoded.
+//
+// The first value of a sequence is packed as is, the following ones as
+// zigzag encoded differences so that sequences with small steps pack well
+// even when their values are large, such as increasing identifiers or timestamps.
+type SeqMode uint8
+
+// Sequence modes.
+const (
+	// SeqDelta packs the difference between a value and its predecessor.
+	SeqDelta SeqMode = iota
+	// SeqDeltaOfDelta packs the difference between the delta of a value and the previous one,
+	// which is zero for values at regular intervals. The second value is packed as with SeqDelta.
+	SeqDeltaOfDelta
+)
+
+// SeqEncoder encodes a sequence of values one at a time.
+//
+// The sequence does not record its mode nor its length: it is decoded
+// with a SeqIterator, or DecodeSeq, using the same mode until its bytes are exhausted.
+type SeqEncoder struct {
+	mode  SeqMode
+	buf   []byte
+	n     int
+	prev  uint64
+	delta int64
+}
+
+// NewSeqEncoder returns a SeqEncoder appending the encoded values to buf.
+func NewSeqEncoder(mode SeqMode, buf []byte) *SeqEncoder {
+	return &SeqEncoder{mode: mode, buf: buf}
+}
+
+// Append adds x to the sequence.
+func (e *SeqEncoder) Append(x uint64) {
+	if e.n == 0 {
+		e.buf = packuint.AppendUint64(e.buf, x)
+	} else {
+		// Differences wrap around so that decreasing values are fine too.
+		d := int64(x - e.prev)
+		if e.mode == SeqDeltaOfDelta && e.n > 1 {
+			e.buf = packuint.AppendInt64(e.buf, d-e.delta)
+		} else {
+			e.buf = packuint.AppendInt64(e.buf, d)
+		}
+		e.delta = d
+	}
+	e.prev = x
+	e.n++
+}
+
+// Len returns the number of values in the sequence.
+func (e *SeqEncoder) Len() int { return e.n }
+
+// Bytes returns the encoded sequence.
+func (e *SeqEncoder) Bytes() []byte { return e.buf }
+
+// SeqIterator decodes a sequence of values one at a time.
+//
+// Typical use is:
+//  it := NewSeqIterator(mode, buf)
+//  for it.Next() {
+//      x := it.Value()
+//  }
+//  if err := it.Err(); err != nil {
+//      ...
+//  }
+type SeqIterator struct {
+	mode  SeqMode
+	buf   []byte
+	n     int
+	x     uint64
+	delta int64
+	err   error
+}
+
+// NewSeqIterator returns a SeqIterator over the sequence encoded in buf.
+func NewSeqIterator(mode SeqMode, buf []byte) *SeqIterator {
+	return &SeqIterator{mode: mode, buf: buf}
+}
+
+// Next decodes the next value of the sequence and reports whether there was one.
+func (it *SeqIterator) Next() bool {
+	if it.err != nil || len(it.buf) == 0 {
+		return false
+	}
+	var n int
+	if it.n == 0 {
+		it.x, n, it.err = DecodeUint64(it.buf)
+	} else {
+		var d int64
+		d, n, it.err = DecodeInt64(it.buf)
+		if it.mode == SeqDeltaOfDelta && it.n > 1 {
+			d += it.delta
+		}
+		it.delta = d
+		it.x += uint64(d)
+	}
+	if it.err != nil {
+		return false
+	}
+	it.buf = it.buf[n:]
+	it.n++
+	return true
+}
+
+// Value returns the value decoded by the last call to Next.
+func (it *SeqIterator) Value() uint64 { return it.x }
+
+// Err returns the error encountered while decoding the sequence, if any.
+// It wraps ErrShortBuffer if the last value is truncated.
+func (it *SeqIterator) Err() error { return it.err }
+
+// EncodeSeq appends the sequence of values of src encoded with mode to dst and returns the extended buffer.
+func EncodeSeq(dst []byte, mode SeqMode, src []uint64) []byte {
+	e := NewSeqEncoder(mode, dst)
+	for _, x := range src {
+		e.Append(x)
+	}
+	return e.Bytes()
+}
+
+// DecodeSeq appends the values of the sequence encoded with mode in src to dst and returns the extended slice.
+// The values decoded before an error are still appended to dst.
+func DecodeSeq(dst []uint64, mode SeqMode, src []byte) ([]uint64, error) {
+	it := NewSeqIterator(mode, src)
+	for it.Next() {
+		dst = append(dst, it.Value())
+	}
+	return dst, it.Err()
+}
@@ -0,0 +1,83 @@
+package packer
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSeq(t *testing.T) {
+	const start = 1600000000000 // milliseconds, packed into 6 bytes
+	regular := make([]uint64, 100)
+	for i := range regular {
+		regular[i] = start + uint64(i)*1000
+	}
+	for _, tc := range []struct {
+		label  string
+		mode   SeqMode
+		values []uint64
+		size   int
+	}{
+		{"empty", SeqDelta, nil, 0},
+		{"single", SeqDelta, []uint64{start}, 6},
+		{"increasing", SeqDelta, []uint64{start, start + 1, start + 3, start + 6}, 6 + 3*2},
+		{"decreasing", SeqDelta, []uint64{start, start - 1, start - 3}, 6 + 2*2},
+		{"wrap around", SeqDelta, []uint64{math.MaxUint64, 0, math.MaxUint64}, 9 + 2*2},
+		{"regular delta", SeqDelta, regular, 6 + 99*3},
+		{"regular delta of delta", SeqDeltaOfDelta, regular, 6 + 3 + 98},
+		{"irregular delta of delta", SeqDeltaOfDelta, []uint64{start, start + 10, start + 15, start + 30}, 6 + 3*2},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			buf := EncodeSeq(nil, tc.mode, tc.values)
+			if got, want := len(buf), tc.size; got != want {
+				t.Fatalf("got %d bytes; want %d", got, want)
+			}
+			got, err := DecodeSeq(nil, tc.mode, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tc.values) || len(got) > 0 && !reflect.DeepEqual(got, tc.values) {
+				t.Fatalf("got %v; want %v", got, tc.values)
+			}
+		})
+	}
+}
+
+func TestSeqStream(t *testing.T) {
+	e := NewSeqEncoder(SeqDeltaOfDelta, []byte{0xFF})
+	for i := uint64(0); i < 10; i++ {
+		e.Append(i * i)
+	}
+	if got, want := e.Len(), 10; got != want {
+		t.Fatalf("got %d values; want %d", got, want)
+	}
+	buf := e.Bytes()
+	if buf[0] != 0xFF {
+		t.Fatal("buffer overwritten")
+	}
+
+	it := NewSeqIterator(SeqDeltaOfDelta, buf[1:])
+	var i uint64
+	for ; it.Next(); i++ {
+		if got, want := it.Value(), i*i; got != want {
+			t.Fatalf("got %d; want %d", got, want)
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != 10 {
+		t.Fatalf("got %d values; want 10", i)
+	}
+
+	// Truncated sequence.
+	buf = EncodeSeq(nil, SeqDelta, []uint64{1, 1 << 40})
+	got, err := DecodeSeq(nil, SeqDelta, buf[:len(buf)-1])
+	if !errors.Is(err, ErrShortBuffer) {
+		t.Fatalf("got %v; want %v", err, ErrShortBuffer)
+	}
+	if want := []uint64{1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v; want %v", got, want)
+	}
+}
@@ -0,0 +1,218 @@
+package packer
+
+import (
+	"io"
+
+	"github.com/pierrec/packer/internal/packuint"
+)
+
+const streamBufSize = 4 << 10
+
+// Encoder writes packed integers to a stream.
+//
+// Values are buffered and Flush must be called once all of them have been written.
+// Errors are sticky: once a write fails, all subsequent calls return the same error.
+type Encoder struct {
+	w   io.Writer
+	buf []byte
+	off int64
+	err error
+}
+
+// NewEncoder returns an Encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w, buf: make([]byte, 0, streamBufSize)}
+}
+
+// Offset returns the number of bytes encoded so far, including the ones not yet flushed.
+func (e *Encoder) Offset() int64 { return e.off }
+
+// Err returns the first error encountered by e.
+func (e *Encoder) Err() error { return e.err }
+
+// Flush writes any buffered data to the underlying io.Writer.
+func (e *Encoder) Flush() error {
+	if e.err != nil || len(e.buf) == 0 {
+		return e.err
+	}
+	n, err := e.w.Write(e.buf)
+	if err == nil && n < len(e.buf) {
+		err = io.ErrShortWrite
+	}
+	if err != nil {
+		e.err = err
+		return err
+	}
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// grow makes room for n bytes in the buffer.
+func (e *Encoder) grow(n int) error {
+	if e.err == nil && cap(e.buf)-len(e.buf) < n {
+		return e.Flush()
+	}
+	return e.err
+}
+
+// WriteUint64 packs x to the stream.
+func (e *Encoder) WriteUint64(x uint64) error {
+	if err := e.grow(9); err != nil {
+		return err
+	}
+	n := len(e.buf)
+	e.buf = packuint.AppendUint64(e.buf, x)
+	e.off += int64(len(e.buf) - n)
+	return nil
+}
+
+// WriteUint32 packs x to the stream.
+func (e *Encoder) WriteUint32(x uint32) error {
+	if err := e.grow(5); err != nil {
+		return err
+	}
+	n := len(e.buf)
+	e.buf = packuint.AppendUint32(e.buf, x)
+	e.off += int64(len(e.buf) - n)
+	return nil
+}
+
+// WriteInt64 packs x to the stream.
+func (e *Encoder) WriteInt64(x int64) error {
+	if err := e.grow(9); err != nil {
+		return err
+	}
+	n := len(e.buf)
+	e.buf = packuint.AppendInt64(e.buf, x)
+	e.off += int64(len(e.buf) - n)
+	return nil
+}
+
+// WriteInt32 packs x to the stream.
+func (e *Encoder) WriteInt32(x int32) error {
+	if err := e.grow(5); err != nil {
+		return err
+	}
+	n := len(e.buf)
+	e.buf = packuint.AppendInt32(e.buf, x)
+	e.off += int64(len(e.buf) - n)
+	return nil
+}
+
+// Decoder reads packed integers from a stream.
+//
+// It reads ahead from its source into its own buffer, so that values are not lost between calls
+// as they can be with successive calls to UnpackUint64From on a reader that is not an io.ByteReader.
+// Errors are sticky: once a read fails, all subsequent calls return the same error.
+// io.EOF is returned when the stream ends on a value boundary, io.ErrUnexpectedEOF otherwise.
+type Decoder struct {
+	r        io.Reader
+	buf      []byte
+	pos, end int
+	off      int64
+	rerr     error // pending read error
+	err      error
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r, buf: make([]byte, streamBufSize)}
+}
+
+// Offset returns the number of bytes decoded so far.
+func (d *Decoder) Offset() int64 { return d.off }
+
+// Err returns the first error encountered by d.
+func (d *Decoder) Err() error { return d.err }
+
+// fill reads from the source until at least n bytes are buffered.
+func (d *Decoder) fill(n int) error {
+	if d.pos > 0 {
+		d.end = copy(d.buf, d.buf[d.pos:d.end])
+		d.pos = 0
+	}
+	for empty := 0; d.end < n; {
+		if d.rerr != nil {
+			return d.rerr
+		}
+		m, err := d.r.Read(d.buf[d.end:])
+		d.end += m
+		d.rerr = err
+		if m == 0 && err == nil {
+			// Same limit as bufio.Reader.
+			if empty++; empty == 100 {
+				d.rerr = io.ErrNoProgress
+			}
+		}
+	}
+	return nil
+}
+
+// next returns the number of bytes of the buffered value at d.pos, as given by packedLen.
+func (d *Decoder) next(packedLen func(byte) int) (int, error) {
+	if d.err != nil {
+		return 0, d.err
+	}
+	if d.pos == d.end {
+		if err := d.fill(1); err != nil {
+			d.err = err
+			return 0, err
+		}
+	}
+	n := packedLen(d.buf[d.pos])
+	if d.end-d.pos < n {
+		if err := d.fill(n); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			d.err = err
+			return 0, err
+		}
+	}
+	d.off += int64(n)
+	return n, nil
+}
+
+// ReadUint64 unpacks the next uint64 from the stream.
+func (d *Decoder) ReadUint64() (uint64, error) {
+	n, err := d.next(packuint.PackedLenUint64)
+	if err != nil {
+		return 0, err
+	}
+	x := packuint.UnpackUint64(d.buf[d.pos], d.buf[d.pos+1:d.pos+n])
+	d.pos += n
+	return x, nil
+}
+
+// ReadUint32 unpacks the next uint32 from the stream.
+func (d *Decoder) ReadUint32() (uint32, error) {
+	n, err := d.next(packuint.PackedLenUint32)
+	if err != nil {
+		return 0, err
+	}
+	x := packuint.UnpackUint32(d.buf[d.pos], d.buf[d.pos+1:d.pos+n])
+	d.pos += n
+	return x, nil
+}
+
+// ReadInt64 unpacks the next int64 from the stream.
+func (d *Decoder) ReadInt64() (int64, error) {
+	n, err := d.next(packuint.PackedLenUint64)
+	if err != nil {
+		return 0, err
+	}
+	x := packuint.UnpackInt64(d.buf[d.pos], d.buf[d.pos+1:d.pos+n])
+	d.pos += n
+	return x, nil
+}
+
+// ReadInt32 unpacks the next int32 from the stream.
+func (d *Decoder) ReadInt32() (int32, error) {
+	n, err := d.next(packuint.PackedLenUint32)
+	if err != nil {
+		return 0, err
+	}
+	x := packuint.UnpackInt32(d.buf[d.pos], d.buf[d.pos+1:d.pos+n])
+	d.pos += n
+	return x, nil
+}
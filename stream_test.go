@@ -0,0 +1,118 @@
+package packer
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"testing/iotest"
+)
+
+func TestEncoderDecoder(t *testing.T) {
+	out := new(bytes.Buffer)
+	enc := NewEncoder(out)
+	const count = 1000 // enough to go over the buffer size
+	var size int64
+	for i := int64(0); i < count; i++ {
+		x := uint64(i * i * i * i)
+		size += int64(PackedSizeUint64(x) + PackedSizeUint32(uint32(i)) + PackedSizeInt64(-i) + PackedSizeInt32(int32(-i)))
+		for _, err := range []error{
+			enc.WriteUint64(x),
+			enc.WriteUint32(uint32(i)),
+			enc.WriteInt64(-i),
+			enc.WriteInt32(int32(-i)),
+		} {
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := enc.Offset(), size; got != want {
+		t.Fatalf("got offset %d; want %d", got, want)
+	}
+	if got, want := int64(out.Len()), size; got != want {
+		t.Fatalf("got %d bytes; want %d", got, want)
+	}
+
+	// Read one byte at a time so that values are split across reads.
+	dec := NewDecoder(iotest.OneByteReader(out))
+	for i := int64(0); i < count; i++ {
+		x, err := dec.ReadUint64()
+		if err != nil || x != uint64(i*i*i*i) {
+			t.Fatalf("got %d, %v; want %d", x, err, i*i*i*i)
+		}
+		y, err := dec.ReadUint32()
+		if err != nil || y != uint32(i) {
+			t.Fatalf("got %d, %v; want %d", y, err, i)
+		}
+		z, err := dec.ReadInt64()
+		if err != nil || z != -i {
+			t.Fatalf("got %d, %v; want %d", z, err, -i)
+		}
+		w, err := dec.ReadInt32()
+		if err != nil || w != int32(-i) {
+			t.Fatalf("got %d, %v; want %d", w, err, -i)
+		}
+	}
+	if got, want := dec.Offset(), size; got != want {
+		t.Fatalf("got offset %d; want %d", got, want)
+	}
+	if _, err := dec.ReadUint64(); err != io.EOF {
+		t.Fatalf("got %v; want %v", err, io.EOF)
+	}
+}
+
+func TestDecoderError(t *testing.T) {
+	buf := AppendUint64(nil, math.MaxUint64)
+	dec := NewDecoder(bytes.NewReader(buf[:5]))
+	if _, err := dec.ReadUint64(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("got %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+	// Errors are sticky.
+	if _, err := dec.ReadUint32(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("got %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+	if got := dec.Offset(); got != 0 {
+		t.Fatalf("got offset %d; want 0", got)
+	}
+
+	// Read errors are returned once the buffered values are consumed.
+	dec = NewDecoder(io.MultiReader(bytes.NewReader(buf), errReader{}))
+	if x, err := dec.ReadUint64(); err != nil || x != math.MaxUint64 {
+		t.Fatalf("got %d, %v; want %d", x, err, uint64(math.MaxUint64))
+	}
+	if _, err := dec.ReadUint64(); err != errIO {
+		t.Fatalf("got %v; want %v", err, errIO)
+	}
+}
+
+var errIO = errors.New("i/o error")
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errIO }
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errIO }
+
+func TestEncoderError(t *testing.T) {
+	enc := NewEncoder(errWriter{})
+	if err := enc.WriteUint64(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := enc.Flush(); err != errIO {
+		t.Fatalf("got %v; want %v", err, errIO)
+	}
+	// Errors are sticky.
+	if err := enc.WriteInt32(1); err != errIO {
+		t.Fatalf("got %v; want %v", err, errIO)
+	}
+	if err := enc.Err(); err != errIO {
+		t.Fatalf("got %v; want %v", err, errIO)
+	}
+}
@@ -1,9 +1,11 @@
 package packer
 
 import (
+	"bytes"
 	"fmt"
 	"io"
-	"reflect"
+	"math/bits"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"text/template"
@@ -31,8 +33,44 @@ const (
 	ErrFieldType      _error = "unsupported field type"
 	ErrFieldOverflow  _error = "too many bits for field type"
 	ErrStructOverflow _error = "struct overflows uint64"
+	ErrFieldTag       _error = "invalid struct tag"
+	ErrFieldDefault   _error = "invalid default value"
+	ErrFieldMigration _error = "field type cannot be migrated"
+	ErrFieldOverlap   _error = "field overlaps another one"
+	ErrFieldOffset    _error = "explicit field offsets not supported"
+	ErrFieldEnum      _error = "invalid enum value"
+	ErrSyntax         _error = "syntax error"
+	ErrUnsupported    _error = "unsupported construct"
+	ErrInvalidValue   _error = "invalid value"
+	ErrNotASlice      _error = "not a slice"
+	ErrFieldValue     _error = "value does not fit the field"
+	ErrTypeRedeclared _error = "type declared more than once"
+	ErrNoType         _error = "no type to generate"
+	ErrStaleCode      _error = "generated code is out of date"
+	ErrShortBuffer    _error = "short buffer"
+	ErrNonCanonical   _error = "non canonical encoding"
+	ErrCorrupt        _error = "corrupt data"
+	ErrClosed         _error = "use of closed encoder"
+	ErrPkgName        _error = "package name not set"
+	ErrNameCollision  _error = "generated names collide"
 )
 
+// SourceError locates an error in a layout description, such as a Kaitai Struct file.
+type SourceError struct {
+	Line, Col int    // position of the error, starting at 1
+	Path      string // JSON pointer to the invalid value of a schema, if any
+	Err       error
+}
+
+func (e *SourceError) private() {}
+func (e *SourceError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("%d:%d: %s: %v", e.Line, e.Col, e.Path, e.Err)
+	}
+	return fmt.Sprintf("%d:%d: %v", e.Line, e.Col, e.Err)
+}
+func (e *SourceError) Unwrap() error { return e.Err }
+
 // GenPackedStruct packs a struct into an uint{8, 16, 32, 64} and generates the code to access its members.
 // The struct must be defined as follow:
 //  - field name is used as the method name to access its value
@@ -47,7 +85,22 @@ const (
 //
 // It returns an error if the struct overflows uint64.
 //
+// Fields are packed starting with the least significant bits, or the most significant ones if config.MSBFirst is set.
+// They are packed in declaration order unless config.Reorder is set, the comments of the type listing the chosen order.
+// The named values of the fields, see Field.Enum, are defined as constants prefixed with the type and field names.
+// The fingerprint of the layout is defined in the <type>Fingerprint constant, see CheckFingerprint.
+//
+// Field tags may define options with the packer key as a comma-separated list of key=value:
+//  - default: value of the field when migrated from a type that does not have it (see GenMigration)
+//  - offset: position of the least significant bit of the field, the following fields being packed after it.
+//    It cannot be used with config.MSBFirst.
+//
+// If config.SQL is set, the type also implements the sql.Scanner and driver.Valuer interfaces.
+// Its value is stored as an int64 holding its bits so that uint64 based types round trip,
+// and scanning a value with reserved or unused bits set fails.
+//
 // ``pkg`` defines the package name used for the generated code. If empty, the package clause is not generated.
+// It must then be set with config.SQL, whose imports are generated with the package clause.
 //
 // Example:
 //  type Header struct{
@@ -65,17 +118,40 @@ const (
 //    (*Header).Flag(bool)
 //    (*Header).LenSet(int)
 func GenPackedStruct(w io.Writer, config *Config, s interface{}) error {
-	werr := func(err error) error { return fmt.Errorf("packer: type %T: %w", s, err) }
-	werrf := func(f string, err error) error { return fmt.Errorf("packer: type %T.%s: %w", s, f, err) }
+	l, err := LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	return GenPackedLayout(w, config, l)
+}
 
+// GenPackedLayout generates the code to access the members of the type described by l.
+// See GenPackedStruct.
+func GenPackedLayout(w io.Writer, config *Config, l *Layout) error {
 	config.init()
+	if config.SQL && config.PkgName == "" {
+		return fmt.Errorf("packer: type %s: %w: required by SQL", l.Name, ErrPkgName)
+	}
 
-	typ := reflect.TypeOf(s)
-	if typ.Kind() == reflect.Ptr {
-		typ = typ.Elem()
+	imports, body, err := genPackedLayout(config, l)
+	if err != nil {
+		return err
 	}
-	if typ.Kind() != reflect.Struct {
-		return werr(ErrNotAStruct)
+	if err := genHeader(w, config, imports); err != nil {
+		return fmt.Errorf("packer: type %s: %w", l.Name, err)
+	}
+	_, err = w.Write(body)
+	return err
+}
+
+// genPackedLayout returns the code of the type described by l, without header,
+// as well as the packages it imports.
+func genPackedLayout(config *Config, l *Layout) ([]string, []byte, error) {
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+	fingerprint := l.Fingerprint()
+	l, err := config.LayoutOf(l)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	type _Field struct {
@@ -85,132 +161,125 @@ func GenPackedStruct(w io.Writer, config *Config, s interface{}) error {
 		Out      string // returned type name
 		Shift    int
 		Mask     string
+		Enum     []EnumValue // constants named after the type and field names
 	}
-	var fields []_Field
-	var descr []struct {
-		string
-		int
-	}
-
-	var size int
-	for i, n := 0, typ.NumField(); i < n; i++ {
-		field := typ.Field(i)
-		if field.Anonymous {
-			return werrf(field.Name, ErrEmbeddedField)
+	fields := make([]_Field, len(l.Fields))
+	typname := fmt.Sprintf("uint%d", l.Size)
+	for i, f := range l.Fields {
+		fields[i] = _Field{
+			TypeName: l.Name,
+			Type:     typname,
+			Name:     f.Name,
+			// Remove local path name.
+			Out:   strings.TrimPrefix(f.Type, config.PkgName+"."),
+			Shift: f.Offset,
+			Mask:  fmt.Sprintf("0x%X", uint64(1)<<f.Bits-1),
 		}
-
-		out := field.Type
-		var outBits int
-		switch field.Type.Kind() {
-		case reflect.Bool:
-			outBits = 1
-		case reflect.Int8, reflect.Int16, reflect.Int32,
-			reflect.Uint8, reflect.Uint16, reflect.Uint32:
-			outBits = out.Bits()
-		case reflect.Array:
-			out = field.Type.Elem()
-			outBits = field.Type.Len()
-			var n int
-			switch out.Kind() {
-			case reflect.Bool:
-				n = 1
-			case reflect.Int, reflect.Uint:
-				// Code generated on 64bits platforms must work on 32bits ones.
-				n = 32
-			case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-				reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-				n = out.Bits()
-			default:
-				return werrf(field.Name, ErrFieldType)
-			}
-			// Make sure that the extracted bits fit into the returned type.
-			if outBits > n {
-				return werrf(field.Name, ErrFieldOverflow)
-			}
-		default:
-			return werrf(field.Name, ErrFieldBadType)
+		for _, e := range f.Enum {
+			e.Name = l.Name + strings.ToUpper(f.Name[:1]) + f.Name[1:] + e.Name
+			fields[i].Enum = append(fields[i].Enum, e)
 		}
+	}
 
-		fields = append(fields, _Field{
-			Name: field.Name,
-			// Remove local path name.
-			Out:   strings.TrimPrefix(out.String(), config.PkgName+"."),
-			Shift: size,
-			Mask:  fmt.Sprintf("0x%X", 1<<outBits-1),
-		})
-		size += outBits
-		descr = append(descr, struct {
-			string
-			int
-		}{field.Name, outBits})
-	}
-
-	var unused int
-	switch {
-	case size <= 0:
-		return werr(ErrEmptyStruct)
-	case size <= 8:
-		unused += 8 - size
-		size = 8
-	case size <= 16:
-		unused += 16 - size
-		size = 16
-	case size <= 32:
-		unused += 32 - size
-		size = 32
-	case size <= 64:
-		unused += 64 - size
-		size = 64
-	default:
-		return werr(ErrStructOverflow)
+	// Packages of the named types of the fields.
+	var imports []string
+	if config.SQL {
+		imports = append(imports, sqlImports...)
 	}
-	typname := fmt.Sprintf("uint%d", size)
-	for i := range fields {
-		fields[i].TypeName = typ.Name()
-		fields[i].Type = typname
+	for i, f := range l.Fields {
+		if f.PkgPath != "" && strings.Contains(fields[i].Out, ".") {
+			imports = append(imports, f.PkgPath)
+		}
 	}
 
-	// Package header.
-	header := []string{config.TopComments}
-	if config.PkgName != "" {
-		line := fmt.Sprintf("package %s\n", config.PkgName)
-		header = append(header, line)
-	}
-	if _, err := fmt.Fprintf(w, strings.Join(header, "\n")); err != nil {
-		return werr(err)
+	buf := new(bytes.Buffer)
+	err = structTemplate.Execute(buf, struct {
+		Comments    string
+		TypeName    string
+		Type        string
+		Fields      []_Field
+		SQL         bool
+		Reserved    string
+		Fingerprint string
+		FpName      string
+	}{
+		layoutComments(l),
+		l.Name,
+		typname,
+		fields,
+		config.SQL,
+		// Reserved and unused bits must not be set in scanned values.
+		fmt.Sprintf("0x%X", l.reserved()),
+		fingerprint,
+		fingerprintName(l.Name),
+	})
+	if err != nil {
+		return nil, nil, werr(err)
 	}
+	return imports, buf.Bytes(), nil
+}
 
-	// Type comments.
+// layoutComments returns the comments describing the fields of l.
+func layoutComments(l *Layout) string {
 	buf := new(strings.Builder)
 	tw := tabwriter.NewWriter(buf, 0, 0, 1, ' ', 0)
-	_, _ = fmt.Fprintf(tw, "//   field\t\tbits\n")
-	_, _ = fmt.Fprintf(tw, "//   -----\t\t----\n")
-	for _, c := range descr {
-		_, _ = fmt.Fprintf(tw, "//   %s\t\t%d\n", c.string, c.int)
+	if l.fixed() || l.Reordered {
+		// Fields may not be contiguous nor in declaration order: show their offsets.
+		_, _ = fmt.Fprintf(tw, "//   field\t\tbits\t\toffset\n")
+		_, _ = fmt.Fprintf(tw, "//   -----\t\t----\t\t------\n")
+		for _, f := range l.Fields {
+			_, _ = fmt.Fprintf(tw, "//   %s\t\t%d\t\t%d\n", f.Name, f.Bits, f.Offset)
+		}
+	} else {
+		_, _ = fmt.Fprintf(tw, "//   field\t\tbits\n")
+		_, _ = fmt.Fprintf(tw, "//   -----\t\t----\n")
+		for _, f := range l.Fields {
+			_, _ = fmt.Fprintf(tw, "//   %s\t\t%d\n", f.Name, f.Bits)
+		}
+	}
+	var used uint64
+	for _, f := range l.Fields {
+		used |= f.Mask()
 	}
-	if unused > 0 {
+	if unused := l.Size - bits.OnesCount64(used); unused > 0 {
 		_, _ = fmt.Fprintf(tw, "//   (unused)\t\t%d\n", unused)
 	}
 	_ = tw.Flush()
-	comments := fmt.Sprintf("// %s is defined as follow:\n%s", typ.Name(), buf.String())
+	if l.Reordered {
+		return fmt.Sprintf("// %s is defined as follow, its fields being reordered:\n%s", l.Name, buf.String())
+	}
+	return fmt.Sprintf("// %s is defined as follow:\n%s", l.Name, buf.String())
+}
 
-	err := structTemplate.Execute(w, struct {
-		Comments string
-		TypeName string
-		Type     string
-		Fields   []_Field
-	}{
-		comments,
-		typ.Name(),
-		typname,
-		fields,
-	})
-	if err != nil {
-		return werr(err)
+// genHeader writes the top comments, package clause and imports of a generated file.
+// Nothing but the top comments is written if config.PkgName is not set.
+// Imports are sorted and written once.
+func genHeader(w io.Writer, config *Config, imports []string) error {
+	header := []string{config.TopComments}
+	if config.PkgName != "" {
+		line := fmt.Sprintf("package %s\n", config.PkgName)
+		header = append(header, line)
+		if len(imports) > 0 {
+			imports = append([]string(nil), imports...)
+			sort.Strings(imports)
+			buf := new(strings.Builder)
+			buf.WriteString("import (\n")
+			for i, imp := range imports {
+				if i > 0 && imp == imports[i-1] {
+					continue
+				}
+				_, _ = fmt.Fprintf(buf, "\t%q\n", imp)
+			}
+			buf.WriteString(")\n")
+			header = append(header, buf.String())
+		}
 	}
-	return nil
+	_, err := io.WriteString(w, strings.Join(header, "\n"))
+	return err
 }
 
+var sqlImports = []string{"database/sql/driver", "fmt", "strconv"}
+
 var structTemplate = template.Must(template.New("struct code gen").Parse(structSource))
 
 const structSource = `
@@ -229,6 +298,19 @@ const structSource = `
 {{.Comments -}}
 type {{.TypeName}} {{.Type}}
 
+// {{.FpName}} is the fingerprint of the layout of {{.TypeName}}, see packer.CheckFingerprint.
+const {{.FpName}} = "{{.Fingerprint}}"
+{{range .Fields}}
+{{- if .Enum}}
+// Values of {{.TypeName}}.{{.Name}}.
+const (
+{{- $out := .Out}}
+{{- range .Enum}}
+	{{.Name}} {{$out}} = {{.Value}}
+{{- end}}
+)
+{{end}}
+{{- end}}
 // Getters.
 {{range .Fields}}
 {{- if not (eq .Name "_") -}}
@@ -240,4 +322,39 @@ func (x {{.TypeName}}) {{.Name}}() {{.Out}} { {{template "body_get" .}} }
 {{- if not (eq .Name "_") -}}
 func (x *{{.TypeName}}) {{.Name}}Set(v {{.Out}}) *{{.TypeName}} { {{template "body_set" .}} }
 {{ end -}}
+{{end}}
+{{- if .SQL}}
+// Value implements the driver.Valuer interface.
+func (x {{.TypeName}}) Value() (driver.Value, error) { return int64(x), nil }
+
+// Scan implements the sql.Scanner interface.
+func (x *{{.TypeName}}) Scan(src interface{}) error {
+	var v uint64
+	switch src := src.(type) {
+	case int64:
+		v = uint64(src)
+	case []byte:
+		return x.Scan(string(src))
+	case string:
+		u, err := strconv.ParseUint(src, 10, 64)
+		if err != nil {
+			// Values may have been stored as negative int64.
+			i, ierr := strconv.ParseInt(src, 10, 64)
+			if ierr != nil {
+				return fmt.Errorf("{{.TypeName}}: %w", err)
+			}
+			u = uint64(i)
+		}
+		v = u
+	default:
+		return fmt.Errorf("{{.TypeName}}: cannot scan %T", src)
+	}
+{{- if ne .Reserved "0x0"}}
+	if v&{{.Reserved}} != 0 {
+		return fmt.Errorf("{{.TypeName}}: reserved bits set in %#x", v)
+	}
+{{- end}}
+	*x = {{.TypeName}}(v)
+	return nil
+}
 {{end}}`
@@ -4,14 +4,33 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io"
-	"os"
 	"reflect"
 	"testing"
 )
 
 //go:generate go run gen.go
 
+// Successive versions of a layout.
+type (
+	Version1 struct {
+		version [4]uint
+		flag    [1]bool
+	}
+	Version2 struct {
+		version [4]uint
+		flag    bool
+		Len     [16]int `packer:"default=10"`
+	}
+	Version3 struct {
+		version  [4]uint
+		flag     bool
+		_        [7]int // reserved
+		Len      [16]int
+		_        [4]int // reserved
+		Checksum [32]uint32 `packer:"default=0xC001CAFE"`
+	}
+)
+
 func TestStruct(t *testing.T) {
 	type tcase struct {
 		in  interface{}
@@ -29,22 +48,17 @@ func TestStruct(t *testing.T) {
 			Uint16 uint16
 			Uint32 uint32
 		}
-		Version1 struct {
-			version [4]uint
-			flag    [1]bool
-		}
-		Version2 struct {
+		MSB struct {
 			version [4]uint
 			flag    bool
 			Len     [16]int
 		}
-		Version3 struct {
-			version  [4]uint
-			flag     bool
-			_        [7]int // reserved
-			Len      [16]int
-			_        [4]int // reserved
-			Checksum [32]uint32
+		Aligned struct {
+			flag  bool
+			kind  [3]uint8
+			ID    uint16
+			level [4]uint8
+			Count [12]uint16
 		}
 		Broken1 struct {
 			X [64]int64
@@ -63,14 +77,28 @@ func TestStruct(t *testing.T) {
 			Version1
 		}
 		Broken6 struct{}
+		Broken7 struct {
+			A [8]uint8
+			B [8]uint8 `packer:"offset=4"`
+		}
 	)
 
+	// Configurations other than the default one.
+	configs := map[string]Config{
+		"Version3": {SQL: true},
+		"MSB":      {MSBFirst: true},
+		"Aligned":  {Reorder: ReorderAlign},
+	}
+
 	for _, tc := range []tcase{
 		{Ints{}, nil},
 		{Uints{}, nil},
 		{Version1{}, nil},
 		{Version2{}, nil},
 		{Version3{}, nil},
+		{Small{}, nil},
+		{MSB{}, nil},
+		{Aligned{}, nil},
 		{0, ErrNotAStruct},
 		{Broken1{}, ErrStructOverflow},
 		{Broken2{}, ErrFieldOverflow},
@@ -78,11 +106,16 @@ func TestStruct(t *testing.T) {
 		{Broken4{}, ErrFieldBadType},
 		{Broken5{}, ErrEmbeddedField},
 		{Broken6{}, ErrEmptyStruct},
+		{Broken7{}, ErrFieldOverlap},
 	} {
-		label := fmt.Sprintf("testpkg/%s_gen.go", reflect.TypeOf(tc.in).Name())
+		name := reflect.TypeOf(tc.in).Name()
+		label := fmt.Sprintf("testpkg/%s_gen.go", name)
 		t.Run(label, func(t *testing.T) {
 			buf := new(bytes.Buffer)
-			err := GenPackedStruct(buf, &Config{PkgName: "testpkg"}, tc.in)
+			config := configs[name]
+			config.PkgName = "testpkg"
+			config.TopComments = goldenConfig().TopComments
+			err := GenPackedStruct(buf, &config, tc.in)
 			switch {
 			case tc.err == nil && err != nil:
 				t.Fatal(err)
@@ -103,19 +136,18 @@ func TestStruct(t *testing.T) {
 				return
 			}
 
-			out, err := os.Create(label)
-			if err != nil {
-				t.Fatal(err)
-			}
-			defer out.Close()
-			_, err = io.Copy(out, buf)
-			if err != nil {
-				t.Fatal(err)
-			}
+			checkGenerated(t, label, buf.Bytes())
 		})
 	}
 }
 
+func TestGenPackedStructSQLPkgName(t *testing.T) {
+	err := GenPackedStruct(new(bytes.Buffer), &Config{SQL: true}, Version3{})
+	if !errors.Is(err, ErrPkgName) {
+		t.Fatalf("got %v; want %v", err, ErrPkgName)
+	}
+}
+
 func ExampleStruct() {
 	// type Header struct {
 	//   version [4]uint
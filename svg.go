@@ -0,0 +1,128 @@
+package packer
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// GenSVG generates the SVG bit map of the struct s packed as defined by GenPackedStruct.
+//
+// The packed value is drawn like its ASCII bit diagram, see GenDiagram, with every field
+// showing its most and least significant bits. Reserved bits are hatched and unused ones dashed.
+func GenSVG(w io.Writer, config *Config, s interface{}) error {
+	l, err := config.LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+
+	config.init()
+
+	const (
+		margin    = 8
+		bitWidth  = 24
+		rowHeight = 48
+		rowGap    = 8
+		charWidth = 7 // approximate width of a label character
+	)
+	type _Rect struct {
+		Class  string
+		Title  string // full label
+		Label  string
+		Hi, Lo int
+		X, Y   int
+		Width  int
+		Mid    int // middle of the rectangle
+		End    int // end of the rectangle
+	}
+	width := diagramRowBits(l)
+	rows := diagramRows(l, width)
+	var rects []_Rect
+	for r, row := range rows {
+		x := margin
+		for _, s := range row {
+			rect := _Rect{
+				Class: "field",
+				Title: s.label(),
+				Label: s.label(),
+				Hi:    s.hi,
+				Lo:    s.lo,
+				X:     x,
+				Y:     margin + r*(rowHeight+rowGap),
+				Width: s.bits() * bitWidth,
+			}
+			rect.Mid, rect.End = x+rect.Width/2, x+rect.Width
+			switch {
+			case s.field == nil:
+				rect.Class = "unused"
+			case s.field.Reserved():
+				rect.Class = "reserved"
+			}
+			if n := rect.Width/charWidth - 1; len(rect.Label) > n {
+				rect.Label = rect.Label[:n] + "…"
+				if n <= 0 {
+					rect.Label = ""
+				}
+			}
+			rects = append(rects, rect)
+			x += rect.Width
+		}
+	}
+
+	err = svgTemplate.Execute(w, struct {
+		Header    string
+		TypeName  string
+		Width     int
+		Height    int
+		RowHeight int
+		Rects     []_Rect
+	}{
+		htmlComment(config.TopComments),
+		l.Name,
+		2*margin + width*bitWidth,
+		2*margin + len(rows)*(rowHeight+rowGap) - rowGap,
+		rowHeight,
+		rects,
+	})
+	if err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+var svgTemplate = template.Must(template.New("svg gen").Parse(svgSource))
+
+const svgSource = `<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+{{.Header -}}
+<title>{{.TypeName}}</title>
+<defs>
+  <pattern id="hatch" width="6" height="6" patternUnits="userSpaceOnUse" patternTransform="rotate(45)">
+    <line x1="0" y1="0" x2="0" y2="6" stroke="#bbb" stroke-width="2"/>
+  </pattern>
+</defs>
+<style>
+  rect { stroke: #333; stroke-width: 1; }
+  rect.field { fill: #e8f0fe; }
+  rect.reserved { fill: url(#hatch); }
+  rect.unused { fill: #f4f4f4; stroke-dasharray: 4 2; }
+  text { font-family: monospace; font-size: 12px; text-anchor: middle; }
+  text.bit { font-size: 9px; fill: #555; }
+  text.hi { text-anchor: start; }
+  text.lo { text-anchor: end; }
+</style>
+{{- range .Rects}}
+<g class="{{.Class}}">
+  <title>{{.Title}}: bits {{.Lo}}-{{.Hi}}</title>
+  <rect class="{{.Class}}" x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{$.RowHeight}}"/>
+  <text x="{{.Mid}}" y="{{.Y}}" dy="30">{{.Label}}</text>
+{{- if eq .Hi .Lo}}
+  <text class="bit" x="{{.Mid}}" y="{{.Y}}" dy="11">{{.Hi}}</text>
+{{- else}}
+  <text class="bit hi" x="{{.X}}" y="{{.Y}}" dx="2" dy="11">{{.Hi}}</text>
+  <text class="bit lo" x="{{.End}}" y="{{.Y}}" dx="-2" dy="11">{{.Lo}}</text>
+{{- end}}
+</g>
+{{- end}}
+</svg>
+`
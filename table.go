@@ -0,0 +1,113 @@
+package packer
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// GenPackedTable generates the name array variable holding the packed values of rows,
+// a slice of structs of the same type, packed as defined by GenPackedStruct.
+// The value of array fields [n]T is their first element, reserved fields are ignored.
+//
+// Each row is commented with its index and field values. If config.TypePkg is set,
+// the packed type is imported from that package instead of being local to config.PkgName.
+//
+// Example:
+//  type Entry struct {
+//    Num [3]uint8
+//    Odd bool
+//  }
+//  GenPackedTable(w, config, "entries", []Entry{{Num: [3]uint8{1}, Odd: true}, {Num: [3]uint8{2}}})
+// results in:
+//  var entries = [...]Entry{
+//    0x09, // 0: Num=1 Odd=true
+//    0x02, // 1: Num=2 Odd=false
+//  }
+func GenPackedTable(w io.Writer, config *Config, name string, rows interface{}) error {
+	v := reflect.ValueOf(rows)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("packer: table %s: %w", name, ErrNotASlice)
+	}
+	l, err := config.LayoutOf(reflect.Zero(v.Type().Elem()).Interface())
+	if err != nil {
+		return err
+	}
+
+	config.init()
+
+	type _Row struct {
+		Value   string
+		Comment string
+	}
+	data := struct {
+		Name     string
+		TypeName string
+		Rows     []_Row
+	}{Name: name, TypeName: l.Name}
+	var imports []string
+	if config.TypePkg != "" {
+		imports = append(imports, config.TypePkg)
+		data.TypeName = path.Base(config.TypePkg) + "." + l.Name
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		row := v.Index(i)
+		var x uint64
+		var comment []string
+		for _, f := range l.Fields {
+			if f.Reserved() {
+				continue
+			}
+			fv, s := fieldValue(row.FieldByName(f.Name))
+			if f.decode(fv) != fv || !f.Signed() && s[0] == '-' {
+				return fmt.Errorf("packer: table %s[%d].%s: %w: %s", name, i, f.Name, ErrFieldValue, s)
+			}
+			x |= fv << f.Offset & f.Mask()
+			comment = append(comment, f.Name+"="+s)
+		}
+		data.Rows = append(data.Rows, _Row{
+			Value:   fmt.Sprintf("0x%0*X", l.Size/4, x),
+			Comment: fmt.Sprintf("%d: %s", i, strings.Join(comment, " ")),
+		})
+	}
+
+	if err := genHeader(w, config, imports); err != nil {
+		return fmt.Errorf("packer: table %s: %w", name, err)
+	}
+	if err := tableTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("packer: table %s: %w", name, err)
+	}
+	return nil
+}
+
+// fieldValue returns the bits of the value of a struct field, as well as its string representation.
+// The value of arrays is their first element.
+func fieldValue(v reflect.Value) (uint64, string) {
+	if v.Kind() == reflect.Array {
+		v = v.Index(0)
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return 1, "true"
+		}
+		return 0, "false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return uint64(v.Int()), fmt.Sprint(v.Int())
+	}
+	return v.Uint(), fmt.Sprint(v.Uint())
+}
+
+var tableTemplate = template.Must(template.New("table code gen").Parse(tableSource))
+
+const tableSource = `
+var {{.Name}} = [...]{{.TypeName}}{
+{{- range .Rows}}
+	{{.Value}}, // {{.Comment}}
+{{- end}}
+}
+`
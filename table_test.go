@@ -0,0 +1,80 @@
+package packer
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenPackedTable(t *testing.T) {
+	rows := []Version3{
+		{version: [4]uint{1}, flag: true, Len: [16]int{2000}, Checksum: [32]uint32{0xC001CAFE}},
+		{version: [4]uint{15}, Len: [16]int{1000}},
+	}
+	buf := new(bytes.Buffer)
+	config := &Config{TopComments: "// Generated.\n", PkgName: "testpkg"}
+	if err := GenPackedTable(buf, config, "versions", rows); err != nil {
+		t.Fatal(err)
+	}
+	const want = `// Generated.
+
+package testpkg
+
+var versions = [...]Version3{
+	0xC001CAFE007D0011, // 0: version=1 flag=true Len=2000 Checksum=3221342974
+	0x00000000003E800F, // 1: version=15 flag=false Len=1000 Checksum=0
+}
+`
+	if got := buf.String(); got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+
+	// Full width signed fields hold negative values.
+	buf.Reset()
+	if err := GenPackedTable(buf, &Config{TopComments: "\n"}, "ints", []Ints{{-1, -2, -3}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "0x00FFFFFFFDFFFEFF, // 0: Int8=-1 Int16=-2 Int32=-3\n"; !strings.Contains(buf.String(), want) {
+		t.Fatalf("got\n%s\nwant %q", buf, want)
+	}
+
+	buf.Reset()
+	config = &Config{TopComments: "\n", PkgName: "other", TypePkg: "example.com/testpkg"}
+	if err := GenPackedTable(buf, config, "versions", rows[:1]); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"import (\n\t\"example.com/testpkg\"\n)\n", "var versions = [...]testpkg.Version3{"} {
+		if got := buf.String(); !strings.Contains(got, want) {
+			t.Fatalf("got\n%s\nwant %q", got, want)
+		}
+	}
+}
+
+func TestGenPackedTableError(t *testing.T) {
+	type Small struct {
+		A [3]int8
+		B [4]uint8
+	}
+	for _, tc := range []struct {
+		label string
+		rows  interface{}
+		err   error
+		msg   string
+	}{
+		{"not a slice", Small{}, ErrNotASlice, "packer: table t: not a slice"},
+		{"not a struct", []int{1}, ErrNotAStruct, "packer: type int: not a struct"},
+		{"overflow", []Small{{}, {B: [4]uint8{16}}}, ErrFieldValue, "packer: table t[1].B: value does not fit the field: 16"},
+		{"negative", []Small{{A: [3]int8{-1}}}, ErrFieldValue, "packer: table t[0].A: value does not fit the field: -1"},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			err := GenPackedTable(new(bytes.Buffer), &Config{}, "t", tc.rows)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("got %v; want %v", err, tc.err)
+			}
+			if got := err.Error(); got != tc.msg {
+				t.Fatalf("got %q; want %q", got, tc.msg)
+			}
+		})
+	}
+}
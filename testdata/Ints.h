@@ -0,0 +1,54 @@
+// Code generated by packer. DO NOT EDIT.
+
+#ifndef PACKER_INTS_H
+#define PACKER_INTS_H
+
+#include <stdbool.h>
+#include <stdint.h>
+
+// Ints is defined as follow:
+//   field     bits
+//   -----     ----
+//   Int8      8
+//   Int16     16
+//   Int32     32
+//   (unused)  8
+typedef uint64_t Ints;
+
+#define INTS_SIZE 8
+#define INTS_RESERVED_MASK UINT64_C(0xFF00000000000000)
+_Static_assert(sizeof(Ints) == INTS_SIZE, "Ints must be 8 bytes");
+
+#define INTS_INT8_SHIFT 0
+#define INTS_INT8_MASK UINT64_C(0xFF)
+#define INTS_INT16_SHIFT 8
+#define INTS_INT16_MASK UINT64_C(0xFFFF)
+#define INTS_INT32_SHIFT 24
+#define INTS_INT32_MASK UINT64_C(0xFFFFFFFF)
+
+// Getters.
+static inline int8_t Ints_Int8(Ints x) {
+	return (int8_t)(x >> INTS_INT8_SHIFT & INTS_INT8_MASK);
+}
+static inline int16_t Ints_Int16(Ints x) {
+	return (int16_t)(x >> INTS_INT16_SHIFT & INTS_INT16_MASK);
+}
+static inline int32_t Ints_Int32(Ints x) {
+	return (int32_t)(x >> INTS_INT32_SHIFT & INTS_INT32_MASK);
+}
+
+// Setters.
+static inline Ints *Ints_Int8Set(Ints *x, int8_t v) {
+	*x = (Ints)((*x & ~(INTS_INT8_MASK << INTS_INT8_SHIFT)) | ((Ints)v & INTS_INT8_MASK) << INTS_INT8_SHIFT);
+	return x;
+}
+static inline Ints *Ints_Int16Set(Ints *x, int16_t v) {
+	*x = (Ints)((*x & ~(INTS_INT16_MASK << INTS_INT16_SHIFT)) | ((Ints)v & INTS_INT16_MASK) << INTS_INT16_SHIFT);
+	return x;
+}
+static inline Ints *Ints_Int32Set(Ints *x, int32_t v) {
+	*x = (Ints)((*x & ~(INTS_INT32_MASK << INTS_INT32_SHIFT)) | ((Ints)v & INTS_INT32_MASK) << INTS_INT32_SHIFT);
+	return x;
+}
+
+#endif // PACKER_INTS_H
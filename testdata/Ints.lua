@@ -0,0 +1,33 @@
+-- Code generated by packer. DO NOT EDIT.
+
+-- Ints is defined as follow:
+--   field     bits
+--   -----     ----
+--   Int8      8
+--   Int16     16
+--   Int32     32
+--   (unused)  8
+local ints_proto = Proto("ints", "Ints")
+
+local fields = {
+  Int8 = ProtoField.int64("ints.Int8", "Int8", base.DEC, nil, UInt64.fromhex("FF")),
+  Int16 = ProtoField.int64("ints.Int16", "Int16", base.DEC, nil, UInt64.fromhex("FFFF00")),
+  Int32 = ProtoField.int64("ints.Int32", "Int32", base.DEC, nil, UInt64.fromhex("FFFFFFFF000000")),
+}
+ints_proto.fields = fields
+
+-- Decodes Ints values stored in 8 bytes in little endian byte order.
+function ints_proto.dissector(buffer, pinfo, tree)
+  if buffer:len() < 8 then
+    return 0
+  end
+  pinfo.cols.protocol = ints_proto.name
+  local value = buffer(0, 8)
+  local subtree = tree:add(ints_proto, value)
+  subtree:add_le(fields.Int8, value)
+  subtree:add_le(fields.Int16, value)
+  subtree:add_le(fields.Int32, value)
+  return 8
+end
+
+return ints_proto
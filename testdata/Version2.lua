@@ -0,0 +1,33 @@
+-- Code generated by packer. DO NOT EDIT.
+
+-- Version2 is defined as follow:
+--   field     bits
+--   -----     ----
+--   version   4
+--   flag      1
+--   Len       16
+--   (unused)  11
+local version2_proto = Proto("version2", "Version2")
+
+local fields = {
+  version = ProtoField.uint32("version2.version", "version", base.DEC, nil, 0xF0000000),
+  flag = ProtoField.bool("version2.flag", "flag", 32, nil, 0x8000000),
+  Len = ProtoField.uint32("version2.Len", "Len", base.DEC, nil, 0x7FFF800),
+}
+version2_proto.fields = fields
+
+-- Decodes Version2 values stored in 4 bytes in big endian byte order.
+function version2_proto.dissector(buffer, pinfo, tree)
+  if buffer:len() < 4 then
+    return 0
+  end
+  pinfo.cols.protocol = version2_proto.name
+  local value = buffer(0, 4)
+  local subtree = tree:add(version2_proto, value)
+  subtree:add(fields.version, value)
+  subtree:add(fields.flag, value)
+  subtree:add(fields.Len, value)
+  return 4
+end
+
+return version2_proto
@@ -0,0 +1,65 @@
+// Code generated by packer. DO NOT EDIT.
+
+#ifndef PACKER_VERSION3_H
+#define PACKER_VERSION3_H
+
+#include <stdbool.h>
+#include <stdint.h>
+
+// Version3 is defined as follow:
+//   field     bits
+//   -----     ----
+//   version   4
+//   flag      1
+//   _         7
+//   Len       16
+//   _         4
+//   Checksum  32
+typedef uint64_t Version3;
+
+#define VERSION3_SIZE 8
+#define VERSION3_RESERVED_MASK UINT64_C(0xF0000FE0)
+_Static_assert(sizeof(Version3) == VERSION3_SIZE, "Version3 must be 8 bytes");
+
+#define VERSION3_VERSION_SHIFT 0
+#define VERSION3_VERSION_MASK UINT64_C(0xF)
+#define VERSION3_FLAG_SHIFT 4
+#define VERSION3_FLAG_MASK UINT64_C(0x1)
+#define VERSION3_LEN_SHIFT 12
+#define VERSION3_LEN_MASK UINT64_C(0xFFFF)
+#define VERSION3_CHECKSUM_SHIFT 32
+#define VERSION3_CHECKSUM_MASK UINT64_C(0xFFFFFFFF)
+
+// Getters.
+static inline uint64_t Version3_version(Version3 x) {
+	return (uint64_t)(x >> VERSION3_VERSION_SHIFT & VERSION3_VERSION_MASK);
+}
+static inline bool Version3_flag(Version3 x) {
+	return (x >> VERSION3_FLAG_SHIFT & VERSION3_FLAG_MASK) != 0;
+}
+static inline int64_t Version3_Len(Version3 x) {
+	return (int64_t)(x >> VERSION3_LEN_SHIFT & VERSION3_LEN_MASK);
+}
+static inline uint32_t Version3_Checksum(Version3 x) {
+	return (uint32_t)(x >> VERSION3_CHECKSUM_SHIFT & VERSION3_CHECKSUM_MASK);
+}
+
+// Setters.
+static inline Version3 *Version3_versionSet(Version3 *x, uint64_t v) {
+	*x = (Version3)((*x & ~(VERSION3_VERSION_MASK << VERSION3_VERSION_SHIFT)) | ((Version3)v & VERSION3_VERSION_MASK) << VERSION3_VERSION_SHIFT);
+	return x;
+}
+static inline Version3 *Version3_flagSet(Version3 *x, bool v) {
+	*x = (Version3)((*x & ~(VERSION3_FLAG_MASK << VERSION3_FLAG_SHIFT)) | ((Version3)v & VERSION3_FLAG_MASK) << VERSION3_FLAG_SHIFT);
+	return x;
+}
+static inline Version3 *Version3_LenSet(Version3 *x, int64_t v) {
+	*x = (Version3)((*x & ~(VERSION3_LEN_MASK << VERSION3_LEN_SHIFT)) | ((Version3)v & VERSION3_LEN_MASK) << VERSION3_LEN_SHIFT);
+	return x;
+}
+static inline Version3 *Version3_ChecksumSet(Version3 *x, uint32_t v) {
+	*x = (Version3)((*x & ~(VERSION3_CHECKSUM_MASK << VERSION3_CHECKSUM_SHIFT)) | ((Version3)v & VERSION3_CHECKSUM_MASK) << VERSION3_CHECKSUM_SHIFT);
+	return x;
+}
+
+#endif // PACKER_VERSION3_H
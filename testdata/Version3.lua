@@ -0,0 +1,41 @@
+-- Code generated by packer. DO NOT EDIT.
+
+-- Version3 is defined as follow:
+--   field     bits
+--   -----     ----
+--   version   4
+--   flag      1
+--   _         7
+--   Len       16
+--   _         4
+--   Checksum  32
+local version3_proto = Proto("version3", "Version3")
+
+local fields = {
+  version = ProtoField.uint64("version3.version", "version", base.DEC, nil, UInt64.fromhex("F")),
+  flag = ProtoField.bool("version3.flag", "flag", 64, nil, UInt64.fromhex("10")),
+  ["reserved-5"] = ProtoField.uint64("version3.reserved-5", "reserved", base.HEX, nil, UInt64.fromhex("FE0")),
+  Len = ProtoField.uint64("version3.Len", "Len", base.DEC, nil, UInt64.fromhex("FFFF000")),
+  ["reserved-28"] = ProtoField.uint64("version3.reserved-28", "reserved", base.HEX, nil, UInt64.fromhex("F0000000")),
+  Checksum = ProtoField.uint64("version3.Checksum", "Checksum", base.DEC, nil, UInt64.fromhex("FFFFFFFF00000000")),
+}
+version3_proto.fields = fields
+
+-- Decodes Version3 values stored in 8 bytes in little endian byte order.
+function version3_proto.dissector(buffer, pinfo, tree)
+  if buffer:len() < 8 then
+    return 0
+  end
+  pinfo.cols.protocol = version3_proto.name
+  local value = buffer(0, 8)
+  local subtree = tree:add(version3_proto, value)
+  subtree:add_le(fields.version, value)
+  subtree:add_le(fields.flag, value)
+  subtree:add_le(fields["reserved-5"], value)
+  subtree:add_le(fields.Len, value)
+  subtree:add_le(fields["reserved-28"], value)
+  subtree:add_le(fields.Checksum, value)
+  return 8
+end
+
+return version3_proto
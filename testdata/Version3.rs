@@ -0,0 +1,55 @@
+// Code generated by packer. DO NOT EDIT.
+
+// Version3 is defined as follow:
+//   field     bits
+//   -----     ----
+//   version   4
+//   flag      1
+//   _         7
+//   Len       16
+//   _         4
+//   Checksum  32
+#[repr(transparent)]
+#[derive(Clone, Copy, Debug, Default, PartialEq, Eq, Hash)]
+pub struct Version3(pub u64);
+
+impl Version3 {
+    /// Bits that are reserved or unused.
+    pub const RESERVED_MASK: u64 = 0xF0000FE0;
+
+    pub const fn version(self) -> u64 {
+        ((self.0 >> 0) & 0xF) as u64
+    }
+
+    pub const fn flag(self) -> bool {
+        (self.0 >> 4) & 0x1 != 0
+    }
+
+    pub const fn len(self) -> i64 {
+        ((self.0 >> 12) & 0xFFFF) as i64
+    }
+
+    pub const fn checksum(self) -> u32 {
+        ((self.0 >> 32) & 0xFFFFFFFF) as u32
+    }
+
+    #[must_use]
+    pub const fn with_version(self, v: u64) -> Self {
+        Self((self.0 & !(0xF << 0)) | (((v as u64) & 0xF) << 0))
+    }
+
+    #[must_use]
+    pub const fn with_flag(self, v: bool) -> Self {
+        Self((self.0 & !(0x1 << 4)) | (((v as u64) & 0x1) << 4))
+    }
+
+    #[must_use]
+    pub const fn with_len(self, v: i64) -> Self {
+        Self((self.0 & !(0xFFFF << 12)) | (((v as u64) & 0xFFFF) << 12))
+    }
+
+    #[must_use]
+    pub const fn with_checksum(self, v: u32) -> Self {
+        Self((self.0 & !(0xFFFFFFFF << 32)) | (((v as u64) & 0xFFFFFFFF) << 32))
+    }
+}
@@ -0,0 +1,42 @@
+// Code generated by packer. DO NOT EDIT.
+
+package testpkg
+
+// Aligned is defined as follow, its fields being reordered:
+//   field     bits  offset
+//   -----     ----  ------
+//   ID        16    0
+//   Count     12    16
+//   level     4     28
+//   kind      3     32
+//   flag      1     35
+//   (unused)  28
+type Aligned uint64
+
+// AlignedFingerprint is the fingerprint of the layout of Aligned, see packer.CheckFingerprint.
+const AlignedFingerprint = "6718457a1b17229f"
+
+// Getters.
+func (x Aligned) ID() uint16    { return uint16(x & 0xFFFF) }
+func (x Aligned) Count() uint16 { return uint16(x >> 16 & 0xFFF) }
+func (x Aligned) level() uint8  { return uint8(x >> 28 & 0xF) }
+func (x Aligned) kind() uint8   { return uint8(x >> 32 & 0x7) }
+func (x Aligned) flag() bool    { return x>>35&1 != 0 }
+
+// Setters.
+func (x *Aligned) IDSet(v uint16) *Aligned { *x = *x&^0xFFFF | Aligned(v)&0xFFFF; return x }
+func (x *Aligned) CountSet(v uint16) *Aligned {
+	*x = *x&^(0xFFF<<16) | (Aligned(v) & 0xFFF << 16)
+	return x
+}
+func (x *Aligned) levelSet(v uint8) *Aligned { *x = *x&^(0xF<<28) | (Aligned(v) & 0xF << 28); return x }
+func (x *Aligned) kindSet(v uint8) *Aligned  { *x = *x&^(0x7<<32) | (Aligned(v) & 0x7 << 32); return x }
+func (x *Aligned) flagSet(v bool) *Aligned {
+	const b = 1 << 35
+	if v {
+		*x = *x&^b | b
+	} else {
+		*x &^= b
+	}
+	return x
+}
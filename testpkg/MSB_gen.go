@@ -0,0 +1,33 @@
+// Code generated by packer. DO NOT EDIT.
+
+package testpkg
+
+// MSB is defined as follow:
+//   field     bits
+//   -----     ----
+//   version   4
+//   flag      1
+//   Len       16
+//   (unused)  11
+type MSB uint32
+
+// MSBFingerprint is the fingerprint of the layout of MSB, see packer.CheckFingerprint.
+const MSBFingerprint = "822202b9e64ef656"
+
+// Getters.
+func (x MSB) version() uint { return uint(x >> 28 & 0xF) }
+func (x MSB) flag() bool    { return x>>27&1 != 0 }
+func (x MSB) Len() int      { return int(x >> 11 & 0xFFFF) }
+
+// Setters.
+func (x *MSB) versionSet(v uint) *MSB { *x = *x&^(0xF<<28) | (MSB(v) & 0xF << 28); return x }
+func (x *MSB) flagSet(v bool) *MSB {
+	const b = 1 << 27
+	if v {
+		*x = *x&^b | b
+	} else {
+		*x &^= b
+	}
+	return x
+}
+func (x *MSB) LenSet(v int) *MSB { *x = *x&^(0xFFFF<<11) | (MSB(v) & 0xFFFF << 11); return x }
@@ -0,0 +1,50 @@
+// Code generated by packer. DO NOT EDIT.
+
+package testpkg
+
+// Packet is defined as follow:
+//
+//	field     bits  offset
+//	-----     ----  ------
+//	kind      3     0
+//	Urgent    1     3
+//	Delta     16    4
+//	Seq       16    32
+//	(unused)  28
+type Packet uint64
+
+// PacketFingerprint is the fingerprint of the layout of Packet, see packer.CheckFingerprint.
+const PacketFingerprint = "8b90084718bdf940"
+
+// Values of Packet.kind.
+const (
+	PacketKindData uint8 = 0
+	PacketKindAck  uint8 = 1
+	PacketKindNack uint8 = 2
+)
+
+// Getters.
+func (x Packet) kind() uint8  { return uint8(x & 0x7) }
+func (x Packet) Urgent() bool { return x>>3&1 != 0 }
+func (x Packet) Delta() int16 { return int16(x >> 4 & 0xFFFF) }
+func (x Packet) Seq() uint16  { return uint16(x >> 32 & 0xFFFF) }
+
+// Setters.
+func (x *Packet) kindSet(v uint8) *Packet { *x = *x&^0x7 | Packet(v)&0x7; return x }
+func (x *Packet) UrgentSet(v bool) *Packet {
+	const b = 1 << 3
+	if v {
+		*x = *x&^b | b
+	} else {
+		*x &^= b
+	}
+	return x
+}
+func (x *Packet) DeltaSet(v int16) *Packet {
+	*x = *x&^(0xFFFF<<4) | (Packet(v) & 0xFFFF << 4)
+	return x
+}
+func (x *Packet) SeqSet(v uint16) *Packet {
+	*x = *x&^(0xFFFF<<32) | (Packet(v) & 0xFFFF << 32)
+	return x
+}
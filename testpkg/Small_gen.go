@@ -0,0 +1,29 @@
+// Code generated by packer. DO NOT EDIT.
+
+package testpkg
+
+// Small is defined as follow:
+//   field     bits
+//   -----     ----
+//   flag      1
+//   Len       8
+//   (unused)  7
+type Small uint16
+
+// SmallFingerprint is the fingerprint of the layout of Small, see packer.CheckFingerprint.
+const SmallFingerprint = "5d40c6bdf6f0cc9d"
+
+// Getters.
+func (x Small) flag() bool { return x&1 != 0 }
+func (x Small) Len() int   { return int(x >> 1 & 0xFF) }
+
+// Setters.
+func (x *Small) flagSet(v bool) *Small {
+	if v {
+		*x |= 1
+	} else {
+		*x &^= 1
+	}
+	return x
+}
+func (x *Small) LenSet(v int) *Small { *x = *x&^(0xFF<<1) | (Small(v) & 0xFF << 1); return x }
@@ -0,0 +1,22 @@
+// Code generated by packer. DO NOT EDIT.
+
+package testpkg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MigrateVersion2ToSmall converts a Version2 value into a Small one.
+func MigrateVersion2ToSmall(x Version2) (Small, error) {
+	var y Small
+	var lost []string
+	y.flagSet(x.flag())
+	if y.LenSet(int(x.Len())); int(y.Len()) != x.Len() {
+		lost = append(lost, "Len")
+	}
+	if len(lost) > 0 {
+		return y, fmt.Errorf("MigrateVersion2ToSmall: data loss on %s", strings.Join(lost, ", "))
+	}
+	return y, nil
+}
@@ -0,0 +1,13 @@
+// Code generated by packer. DO NOT EDIT.
+
+package testpkg
+
+// MigrateVersion2ToVersion3 converts a Version2 value into a Version3 one.
+func MigrateVersion2ToVersion3(x Version2) (Version3, error) {
+	var y Version3
+	y.versionSet(x.version())
+	y.flagSet(x.flag())
+	y.LenSet(x.Len())
+	y.ChecksumSet(0xC001CAFE)
+	return y, nil
+}
@@ -0,0 +1,140 @@
+package testpkg
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDriver stores the values inserted into a single column table.
+// Queries starting with INSERT take one argument, any other query returns all values.
+type fakeDriver struct {
+	mu   sync.Mutex
+	rows []driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{c.d, strings.HasPrefix(query, "INSERT")}, nil
+}
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type fakeStmt struct {
+	d      *fakeDriver
+	insert bool
+}
+
+func (fakeStmt) Close() error { return nil }
+
+func (s fakeStmt) NumInput() int {
+	if s.insert {
+		return 1
+	}
+	return 0
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.rows = append(s.d.rows, args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	return &fakeRows{rows: append([]driver.Value(nil), s.d.rows...)}, nil
+}
+
+type fakeRows struct{ rows []driver.Value }
+
+func (*fakeRows) Columns() []string { return []string{"v"} }
+func (*fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	return nil
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("packer_fake", fake)
+}
+
+func TestSQL(t *testing.T) {
+	db, err := sql.Open("packer_fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	reset := func(rows ...driver.Value) {
+		fake.mu.Lock()
+		fake.rows = rows
+		fake.mu.Unlock()
+	}
+	scan := func() (Version3, error) {
+		var v Version3
+		err := db.QueryRow("SELECT v").Scan(&v)
+		return v, err
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		reset()
+		var v Version3
+		// The checksum sets the high bit, which does not fit an int64.
+		v.versionSet(9).flagSet(true).LenSet(1000).ChecksumSet(0xDEADBEEF)
+		if _, err := db.Exec("INSERT v", v); err != nil {
+			t.Fatal(err)
+		}
+		got, err := scan()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != v {
+			t.Fatalf("got %#x; want %#x", uint64(got), uint64(v))
+		}
+	})
+
+	var want Version3
+	want.LenSet(7).ChecksumSet(0xFFFFFFFF)
+	for _, tc := range []struct {
+		label string
+		row   driver.Value
+		err   bool
+	}{
+		{"uint64 string", "18446744069414612992", false},
+		{"int64 string", "-4294938624", false},
+		{"int64 bytes", []byte("-4294938624"), false},
+		{"reserved bits", int64(want) | 1<<5, true},
+		{"reserved bits string", "18446744069414613024", true},
+		{"invalid string", "not a number", true},
+		{"null", nil, true},
+		{"float", 1.5, true},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			reset(tc.row)
+			got, err := scan()
+			switch {
+			case tc.err && err == nil:
+				t.Fatalf("expected error not found, got %#x", uint64(got))
+			case !tc.err && err != nil:
+				t.Fatal(err)
+			case !tc.err && got != want:
+				t.Fatalf("got %#x; want %#x", uint64(got), uint64(want))
+			}
+		})
+	}
+}
@@ -54,3 +54,74 @@ func TestGen(t *testing.T) {
 		})
 	}
 }
+
+func TestMigrate(t *testing.T) {
+	var v1 Version1
+	v1.versionSet(5).flagSet(true)
+	v2, err := MigrateVersion1ToVersion2(v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v2.version() != 5 || !v2.flag() || v2.Len() != 10 {
+		t.Fatalf("Version2: got %d %v %d; want 5 true 10", v2.version(), v2.flag(), v2.Len())
+	}
+
+	v2.LenSet(1000)
+	v3, err := MigrateVersion2ToVersion3(v2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v3.version() != 5 || !v3.flag() || v3.Len() != 1000 || v3.Checksum() != 0xC001CAFE {
+		t.Fatalf("Version3: got %d %v %d %X; want 5 true 1000 C001CAFE",
+			v3.version(), v3.flag(), v3.Len(), v3.Checksum())
+	}
+
+	if _, err := MigrateVersion2ToSmall(v2); err == nil {
+		t.Fatal("expected data loss not reported")
+	}
+	v2.LenSet(200)
+	s, err := MigrateVersion2ToSmall(v2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.flag() || s.Len() != 200 {
+		t.Fatalf("Small: got %v %d; want true 200", s.flag(), s.Len())
+	}
+}
+
+func TestMSB(t *testing.T) {
+	var x MSB
+	x.versionSet(0xF)
+	if x != 0xF<<28 {
+		t.Fatalf("got %X; want %X", uint32(x), 0xF<<28)
+	}
+	x.flagSet(true).LenSet(1000)
+	if x.version() != 0xF || !x.flag() || x.Len() != 1000 {
+		t.Fatalf("got %d %v %d; want 15 true 1000", x.version(), x.flag(), x.Len())
+	}
+}
+
+func TestPacket(t *testing.T) {
+	var x Packet
+	x.kindSet(PacketKindNack).UrgentSet(true).DeltaSet(0x7FF).SeqSet(0xBEEF)
+	if want := Packet(0xBEEF<<32 | 0x7FF<<4 | 1<<3 | 2); x != want {
+		t.Fatalf("got %X; want %X", uint64(x), uint64(want))
+	}
+	if x.kind() != PacketKindNack || !x.Urgent() || x.Delta() != 0x7FF || x.Seq() != 0xBEEF {
+		t.Fatalf("got %d %v %d %X; want 2 true 2047 BEEF", x.kind(), x.Urgent(), x.Delta(), x.Seq())
+	}
+	if x.DeltaSet(-1); x.Delta() != -1 || x.Seq() != 0xBEEF {
+		t.Fatalf("got %d %X; want -1 BEEF", x.Delta(), x.Seq())
+	}
+}
+
+func TestAligned(t *testing.T) {
+	var x Aligned
+	x.IDSet(0xABCD).CountSet(0xFFF).levelSet(9).kindSet(5).flagSet(true)
+	if uint16(x) != 0xABCD || uint8(x>>32) != 5|1<<3 {
+		t.Fatalf("got %X; want ID in the first 2 bytes and kind, flag in the fifth", uint64(x))
+	}
+	if x.ID() != 0xABCD || x.Count() != 0xFFF || x.level() != 9 || x.kind() != 5 || !x.flag() {
+		t.Fatalf("got %X %X %d %d %v", x.ID(), x.Count(), x.level(), x.kind(), x.flag())
+	}
+}
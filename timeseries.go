@@ -0,0 +1,228 @@
+package packer
+
+import (
+	"io"
+
+	"github.com/pierrec/packer/internal/packuint"
+	"github.com/pierrec/packer/iobyte"
+)
+
+// Time series are encoded as in Facebook's Gorilla: timestamps as the difference
+// between their delta and the previous one, the first delta being 0,
+// and values as the XOR with the previous one, see packuint.FloatEncoder.
+//
+// The delta of delta of each timestamp is prefixed with a variable number of control bits:
+//  0      delta of delta is 0
+//  10     followed by 7 bits for [-63,64]
+//  110    followed by 9 bits for [-255,256]
+//  1110   followed by 12 bits for [-2047,2048]
+//  11110  followed by 64 bits
+//  11111  end of the series
+// The series ends on a byte boundary so that another one can follow it.
+
+// tsBuckets are the delta of delta ranges using less than 64 bits.
+var tsBuckets = [...]struct {
+	ctrl     uint64
+	ctrlBits int
+	min, max int64
+	bits     int
+}{
+	{0b10, 2, -63, 64, 7},
+	{0b110, 3, -255, 256, 9},
+	{0b1110, 4, -2047, 2048, 12},
+}
+
+const (
+	tsCtrlRaw = 0b11110
+	tsCtrlEnd = 0b11111
+	tsCtrlLen = 5
+)
+
+// TimeSeriesEncoder writes points made of a timestamp and a float64 value.
+//
+// Timestamps are usually increasing at regular intervals but they do not have to.
+// Close must be called once all points have been written.
+// Errors are sticky: once a write fails, all subsequent calls return the same error.
+type TimeSeriesEncoder struct {
+	w        *packuint.BitWriter
+	done     func(*error)
+	values   *packuint.FloatEncoder
+	n        int
+	t, delta int64
+	err      error
+}
+
+// NewTimeSeriesEncoder returns a TimeSeriesEncoder writing to w.
+func NewTimeSeriesEncoder(w io.Writer) *TimeSeriesEncoder {
+	bw, done := iobyte.NewWriter(w)
+	bits := packuint.NewBitWriter(bw)
+	return &TimeSeriesEncoder{
+		w:      bits,
+		done:   done,
+		values: packuint.NewFloatEncoder(bits),
+	}
+}
+
+// Len returns the number of points written so far.
+func (e *TimeSeriesEncoder) Len() int { return e.n }
+
+// Write adds the point at t with value v to the series.
+func (e *TimeSeriesEncoder) Write(t int64, v float64) error {
+	if e.err != nil {
+		return e.err
+	}
+	var delta int64
+	if e.n > 0 {
+		delta = t - e.t
+	}
+	dod := delta - e.delta
+	if e.n == 0 {
+		// The first timestamp is a delta of delta to 0.
+		dod = t
+	}
+	if e.err = e.writeDelta(dod); e.err != nil {
+		return e.err
+	}
+	if e.err = e.values.Encode(v); e.err != nil {
+		return e.err
+	}
+	e.t, e.delta = t, delta
+	e.n++
+	return nil
+}
+
+func (e *TimeSeriesEncoder) writeDelta(dod int64) error {
+	if dod == 0 {
+		return e.w.WriteBits(0, 1)
+	}
+	for _, b := range tsBuckets {
+		if dod >= b.min && dod <= b.max {
+			return e.w.WriteBits(b.ctrl<<b.bits|uint64(dod-b.min), b.ctrlBits+b.bits)
+		}
+	}
+	if err := e.w.WriteBits(tsCtrlRaw, tsCtrlLen); err != nil {
+		return err
+	}
+	return e.w.WriteBits(uint64(dod), 64)
+}
+
+// Close ends the series and flushes it to the underlying io.Writer, which is not closed.
+func (e *TimeSeriesEncoder) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	err := e.w.WriteBits(tsCtrlEnd, tsCtrlLen)
+	if err == nil {
+		err = e.w.Flush()
+	}
+	e.done(&err)
+	if err != nil {
+		e.err = err
+		return err
+	}
+	e.err = ErrClosed
+	return nil
+}
+
+// TimeSeriesDecoder reads the points of a series written by a TimeSeriesEncoder.
+//
+// Typical use is:
+//  d := NewTimeSeriesDecoder(r)
+//  for d.Next() {
+//      t, v := d.At()
+//  }
+//  if err := d.Err(); err != nil {
+//      ...
+//  }
+type TimeSeriesDecoder struct {
+	r        *packuint.BitReader
+	values   *packuint.FloatDecoder
+	n        int
+	t, delta int64
+	v        float64
+	end      bool
+	err      error
+}
+
+// NewTimeSeriesDecoder returns a TimeSeriesDecoder reading from r.
+//
+// If r is not an io.ByteReader, it is buffered and may be read past the end of the series.
+func NewTimeSeriesDecoder(r io.Reader) *TimeSeriesDecoder {
+	bits := packuint.NewBitReader(iobyte.NewReader(r))
+	return &TimeSeriesDecoder{
+		r:      bits,
+		values: packuint.NewFloatDecoder(bits),
+	}
+}
+
+// Next decodes the next point of the series and reports whether there was one.
+// It returns false at the end of the series or on error.
+func (d *TimeSeriesDecoder) Next() bool {
+	if d.end || d.err != nil {
+		return false
+	}
+	dod, err := d.readDelta()
+	if err != nil {
+		if err == io.EOF && d.n > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != io.EOF {
+			d.err = err
+		}
+		d.end = true
+		return false
+	}
+	if d.end {
+		return false
+	}
+	if d.n == 0 {
+		d.t = dod
+	} else {
+		d.delta += dod
+		d.t += d.delta
+	}
+	if d.v, err = d.values.Decode(); err != nil {
+		d.err = packuint.UnexpectedEOF(err)
+		return false
+	}
+	d.n++
+	return true
+}
+
+func (d *TimeSeriesDecoder) readDelta() (int64, error) {
+	// Count the leading control bits.
+	var ones int
+	for ; ones < tsCtrlLen; ones++ {
+		b, err := d.r.ReadBits(1)
+		if err != nil {
+			if err == io.EOF && ones > 0 {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		if b == 0 {
+			break
+		}
+	}
+	switch ones {
+	case 0:
+		return 0, nil
+	case tsCtrlLen - 1:
+		x, err := d.r.ReadBits(64)
+		return int64(x), packuint.UnexpectedEOF(err)
+	case tsCtrlLen:
+		d.end = true
+		d.r.Align()
+		return 0, nil
+	}
+	b := tsBuckets[ones-1]
+	x, err := d.r.ReadBits(b.bits)
+	return int64(x) + b.min, packuint.UnexpectedEOF(err)
+}
+
+// At returns the point decoded by the last call to Next.
+func (d *TimeSeriesDecoder) At() (t int64, v float64) { return d.t, d.v }
+
+// Err returns the error encountered while decoding the series, if any.
+// The end of the input before any point is not an error.
+func (d *TimeSeriesDecoder) Err() error { return d.err }
@@ -0,0 +1,126 @@
+package packer
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+)
+
+type point struct {
+	t int64
+	v float64
+}
+
+func encodeSeries(t *testing.T, w io.Writer, points []point) {
+	t.Helper()
+	e := NewTimeSeriesEncoder(w)
+	for _, p := range points {
+		if err := e.Write(p.t, p.v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := e.Len(), len(points); got != want {
+		t.Fatalf("got %d points; want %d", got, want)
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func decodeSeries(t *testing.T, r io.Reader, want []point) {
+	t.Helper()
+	d := NewTimeSeriesDecoder(r)
+	var i int
+	for ; d.Next(); i++ {
+		ts, v := d.At()
+		if i >= len(want) {
+			t.Fatalf("too many points: %d, %v", ts, v)
+		}
+		// Compare the bits to handle NaN.
+		if p := want[i]; ts != p.t || math.Float64bits(v) != math.Float64bits(p.v) {
+			t.Fatalf("point %d: got %d, %v; want %d, %v", i, ts, v, p.t, p.v)
+		}
+	}
+	if err := d.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if i != len(want) {
+		t.Fatalf("got %d points; want %d", i, len(want))
+	}
+}
+
+func TestTimeSeries(t *testing.T) {
+	const start = 1600000000 // seconds
+	regular := make([]point, 1000)
+	for i := range regular {
+		regular[i] = point{start + int64(i)*60, 20 + float64(i/100)/2}
+	}
+	for _, tc := range []struct {
+		label  string
+		points []point
+		size   int
+	}{
+		{"empty", nil, 1},
+		{"single", []point{{start, 1.5}}, 13},
+		{"regular", regular, 274},
+		{"irregular", []point{
+			{start, 1}, {start + 10, 1.5}, {start + 15, -1}, {start + 100, 1e300},
+			{start + 3000, 0}, {start - 1, math.Inf(1)}, {math.MinInt64, math.NaN()},
+			{math.MaxInt64, math.Copysign(0, -1)}, {0, math.SmallestNonzeroFloat64},
+		}, 111},
+	} {
+		t.Run(tc.label, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			encodeSeries(t, buf, tc.points)
+			if got, want := buf.Len(), tc.size; got != want {
+				t.Errorf("got %d bytes; want %d", got, want)
+			}
+			decodeSeries(t, buf, tc.points)
+		})
+	}
+}
+
+func TestTimeSeriesStream(t *testing.T) {
+	series := [][]point{
+		{{1, 1}, {2, 2}, {3, 3}},
+		nil,
+		{{10, -1}, {20, -1}},
+	}
+	buf := new(bytes.Buffer)
+	for _, points := range series {
+		encodeSeries(t, buf, points)
+	}
+	for _, points := range series {
+		decodeSeries(t, buf, points)
+	}
+	if buf.Len() > 0 {
+		t.Fatalf("%d bytes left", buf.Len())
+	}
+}
+
+func TestTimeSeriesError(t *testing.T) {
+	buf := new(bytes.Buffer)
+	e := NewTimeSeriesEncoder(buf)
+	for i := int64(0); i < 10; i++ {
+		if err := e.Write(i, float64(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Write(10, 10); err != ErrClosed {
+		t.Fatalf("got %v; want %v", err, ErrClosed)
+	}
+
+	data := buf.Bytes()
+	for n := 1; n < len(data); n++ {
+		d := NewTimeSeriesDecoder(bytes.NewReader(data[:n]))
+		for d.Next() {
+		}
+		if err := d.Err(); err != io.ErrUnexpectedEOF {
+			t.Fatalf("%d bytes: got %v; want %v", n, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package packer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"text/template"
+)
+
+// GenTypeScript generates the TypeScript class giving access to the members of the struct s
+// packed as defined by GenPackedStruct.
+//
+// The class wraps a number for types up to 32 bits and a bigint for 64 bits types.
+// Its getters and setters are named after the Go methods and follow their semantics:
+// values are truncated to the field size when set and only sign extended when read
+// if the field uses all the bits of its type.
+// Fields returning an {u}int64 are exposed as bigint, all others as number or boolean.
+//
+// The static fromBytes method reads the packed value using config.ByteOrder.
+func GenTypeScript(w io.Writer, config *Config, s interface{}) error {
+	l, err := config.LayoutOf(s)
+	if err != nil {
+		return err
+	}
+	werr := func(err error) error { return fmt.Errorf("packer: type %s: %w", l.Name, err) }
+
+	config.init()
+
+	big := l.Size == 64
+	// literal returns v as a constant of the class value type.
+	literal := func(v uint64) string {
+		if big {
+			return fmt.Sprintf("0x%Xn", v)
+		}
+		return fmt.Sprintf("0x%X", v)
+	}
+
+	type _Field struct {
+		Name  string
+		Out   string // returned TypeScript type
+		Get   string // getter expression
+		Set   string // setter expression for v
+		Shift string
+		Mask  string
+	}
+	var fields []_Field
+	for _, f := range l.Fields {
+		if f.Reserved() {
+			continue
+		}
+		field := _Field{
+			Name:  f.Name,
+			Out:   "number",
+			Shift: fmt.Sprint(f.Offset),
+			Mask:  literal(uint64(1)<<f.Bits - 1),
+		}
+		// Extracted bits, as the class value type.
+		if big {
+			field.Shift += "n"
+		}
+		var bits string
+		if big {
+			bits = fmt.Sprintf("(this.value >> %s) & %s", field.Shift, field.Mask)
+		} else {
+			bits = fmt.Sprintf("((this.value >>> %s) & %s) >>> 0", field.Shift, field.Mask)
+		}
+		switch n := kindBits(f.Kind); {
+		case f.Kind == reflect.Bool:
+			field.Out = "boolean"
+			if big {
+				field.Get = fmt.Sprintf("(%s) !== 0n", bits)
+				field.Set = "(v ? 1n : 0n)"
+			} else {
+				field.Get = fmt.Sprintf("(%s) !== 0", bits)
+				field.Set = "(v ? 1 : 0)"
+			}
+		case n == 64:
+			field.Out = "bigint"
+			if !big {
+				bits = fmt.Sprintf("BigInt(%s)", bits)
+			}
+			if f.Signed() && f.Bits == 64 {
+				bits = fmt.Sprintf("BigInt.asIntN(64, %s)", bits)
+			}
+			field.Get = bits
+			if big {
+				field.Set = "v"
+			} else {
+				field.Set = "Number(BigInt.asUintN(32, v))"
+			}
+		default:
+			if big {
+				bits = fmt.Sprintf("Number(%s)", bits)
+			}
+			if f.Signed() && f.Kind != reflect.Int && f.Bits == n {
+				// Sign extend full width values.
+				bits = fmt.Sprintf("((%s) << %d) >> %[2]d", bits, 32-n)
+			}
+			field.Get = bits
+			if big {
+				field.Set = "BigInt(Math.trunc(v))"
+			} else {
+				field.Set = "v"
+			}
+		}
+		fields = append(fields, field)
+	}
+
+	var getter, setter string
+	switch l.Size {
+	case 8:
+		getter, setter = "getUint8(offset)", "setUint8(offset, this.value)"
+	case 16:
+		getter, setter = "getUint16(offset, %t)", "setUint16(offset, this.value, %t)"
+	case 32:
+		getter, setter = "getUint32(offset, %t)", "setUint32(offset, this.value, %t)"
+	case 64:
+		getter, setter = "getBigUint64(offset, %t)", "setBigUint64(offset, this.value, %t)"
+	}
+	if l.Size > 8 {
+		little := config.ByteOrder == binary.LittleEndian
+		getter = fmt.Sprintf(getter, little)
+		setter = fmt.Sprintf(setter, little)
+	}
+
+	typ, zero := "number", "0"
+	if big {
+		typ, zero = "bigint", "0n"
+	}
+	err = tsTemplate.Execute(w, struct {
+		Header    string
+		Comments  string
+		TypeName  string
+		Type      string
+		Zero      string
+		Size      int
+		ByteOrder string
+		Reserved  string
+		Getter    string
+		Setter    string
+		Fields    []_Field
+	}{
+		config.TopComments,
+		layoutComments(l),
+		l.Name,
+		typ,
+		zero,
+		l.Size / 8,
+		byteOrderName(config.ByteOrder),
+		literal(l.reserved() & (^uint64(0) >> (64 - l.Size))),
+		getter,
+		setter,
+		fields,
+	})
+	if err != nil {
+		return werr(err)
+	}
+	return nil
+}
+
+// byteOrderName returns the name of the byte order as used in comments.
+func byteOrderName(order binary.ByteOrder) string {
+	if order == binary.LittleEndian {
+		return "little endian"
+	}
+	return "big endian"
+}
+
+var tsTemplate = template.Must(template.New("typescript code gen").Parse(tsSource))
+
+const tsSource = `{{.Header}}
+{{.Comments -}}
+export class {{.TypeName}} {
+  /** Size in bytes of the packed value. */
+  static readonly SIZE = {{.Size}};
+  /** Bits that are reserved or unused. */
+  static readonly RESERVED_MASK = {{.Reserved}};
+
+  value: {{.Type}};
+
+  constructor(value: {{.Type}} = {{.Zero}}) {
+    this.value = value;
+  }
+
+  /** Reads an instance from b at the given offset, in {{.ByteOrder}} byte order. */
+  static fromBytes(b: Uint8Array, offset: number = 0): {{.TypeName}} {
+    const view = new DataView(b.buffer, b.byteOffset, b.byteLength);
+    return new {{.TypeName}}(view.{{.Getter}});
+  }
+
+  /** Writes the value into b at the given offset, in {{.ByteOrder}} byte order. */
+  toBytes(b: Uint8Array = new Uint8Array({{.TypeName}}.SIZE), offset: number = 0): Uint8Array {
+    const view = new DataView(b.buffer, b.byteOffset, b.byteLength);
+    view.{{.Setter}};
+    return b;
+  }
+{{range .Fields}}
+  {{.Name}}(): {{.Out}} {
+    return {{.Get}};
+  }
+{{end}}
+{{- range .Fields}}
+  {{.Name}}Set(v: {{.Out}}): this {
+{{- if eq $.Type "bigint"}}
+    this.value = (this.value & ~({{.Mask}} << {{.Shift}})) | ((({{.Set}}) & {{.Mask}}) << {{.Shift}});
+{{- else}}
+    this.value = ((this.value & ~({{.Mask}} << {{.Shift}})) | ((({{.Set}}) & {{.Mask}}) << {{.Shift}})) >>> 0;
+{{- end}}
+    return this;
+  }
+{{end -}}
+}
+`
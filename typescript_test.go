@@ -0,0 +1,90 @@
+package packer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGenTypeScript(t *testing.T) {
+	src := new(bytes.Buffer)
+	for _, s := range []interface{}{Ints{}, Version2{}, Version3{}} {
+		config := goldenConfig()
+		if _, ok := s.(Version2); ok {
+			config.ByteOrder = binary.BigEndian
+		}
+		buf := new(bytes.Buffer)
+		if err := GenTypeScript(buf, config, s); err != nil {
+			t.Fatal(err)
+		}
+		checkGolden(t, reflect.TypeOf(s).Name()+".ts", buf.Bytes())
+		src.Write(buf.Bytes())
+	}
+
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("no node: only the golden files are checked")
+	}
+	// The code is type checked and compiled with tsc, or run by node with its types stripped,
+	// which does not check them.
+	var args []string
+	file := "main.ts"
+	tsc, err := exec.LookPath("tsc")
+	if err != nil {
+		if exec.Command(node, "--experimental-strip-types", "-e", "").Run() != nil {
+			t.Skip("no tsc and node cannot strip types: only the golden files are checked")
+		}
+		// Module syntax is only detected by all versions for .mts files.
+		args, file = []string{"--experimental-strip-types"}, "main.mts"
+	}
+	dir, err := ioutil.TempDir("", "packer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var v2 uint32 = 0xFFFF<<5 | 1<<4
+	checkLang(t, map[string]string{
+		"ints": `const i = new Ints().Int8Set(-3).Int16Set(-1000).Int32Set(-100000);
+console.log(i.value.toString(16), i.Int8(), i.Int16(), i.Int32());`,
+		"version3": `const v = new Version3().versionSet(9).flagSet(true).LenSet(1000).ChecksumSet(0xDEADBEEF);
+console.log(v.value.toString(16), v.version(), v.flag() ? 1 : 0, v.Len(), v.Checksum().toString(16));`,
+		"reserved": `v.flagSet(false);
+console.log(v.flag() ? 1 : 0, (v.value & Version3.RESERVED_MASK).toString(16));`,
+	}, []langCase{
+		{"bytes", `const b = v.toBytes();
+console.log(Version3.fromBytes(b).value === v.value, b[0]);`,
+			fmt.Sprintf("true %d", byte(langV3&^(1<<4)))},
+		{"big endian", `const v2 = new Version2().LenSet(-1).flagSet(true);
+console.log(v2.Len(), v2.value.toString(16), v2.toBytes()[3], Version2.fromBytes(v2.toBytes()).flag());`,
+			fmt.Sprintf("%d %x %d true", 0xFFFF, v2, byte(v2))},
+	}, func(body string) []byte {
+		path := filepath.Join(dir, file)
+		if err := ioutil.WriteFile(path, []byte(src.String()+"\n"+body+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if tsc != "" {
+			outDir := filepath.Join(dir, "out")
+			cmd := exec.Command(tsc, "--strict", "--noEmitOnError", "--target", "es2020", "--module", "commonjs", "--outDir", outDir, path)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("%v: %s", err, out)
+			}
+			path = filepath.Join(outDir, "main.js")
+		}
+		// Warnings are written to stderr.
+		out, err := exec.Command(node, append(args, path)...).Output()
+		if err != nil {
+			if e, ok := err.(*exec.ExitError); ok {
+				t.Fatalf("%v: %s", err, e.Stderr)
+			}
+			t.Fatal(err)
+		}
+		return out
+	})
+}
@@ -21,6 +21,21 @@ func PackUint64(buf []byte, x uint64) int {
 	return packuint.PackUint64(minBuf(buf, 9), x)
 }
 
+// AppendUint64 appends the packed x to dst and returns the extended buffer.
+func AppendUint64(dst []byte, x uint64) []byte {
+	return packuint.AppendUint64(dst, x)
+}
+
+// PackedSizeUint64 returns the number of bytes used to pack x.
+func PackedSizeUint64(x uint64) int {
+	return packuint.PackedSizeUint64(x)
+}
+
+// PackedLenUint64 returns the number of bytes of a packed uint64 given its first byte.
+func PackedLenUint64(bitmap byte) int {
+	return packuint.PackedLenUint64(bitmap)
+}
+
 // PackUint64To packs x to w.
 //
 // buf is used as scratch space if it has at least 9 bytes in capacity.
@@ -34,6 +49,7 @@ func UnpackUint64(buf []byte) uint64 {
 }
 
 // UnpackUint64From unpacks an uint32 from r.
+// Use a Decoder to read several values from the same reader.
 func UnpackUint64From(r io.Reader, buf []byte) (uint64, error) {
 	return packuint.UnpackUint64From(iobyte.NewReader(r), buf)
 }
@@ -45,6 +61,21 @@ func PackUint32(buf []byte, x uint32) int {
 	return packuint.PackUint32(minBuf(buf, 5), x)
 }
 
+// AppendUint32 appends the packed x to dst and returns the extended buffer.
+func AppendUint32(dst []byte, x uint32) []byte {
+	return packuint.AppendUint32(dst, x)
+}
+
+// PackedSizeUint32 returns the number of bytes used to pack x.
+func PackedSizeUint32(x uint32) int {
+	return packuint.PackedSizeUint32(x)
+}
+
+// PackedLenUint32 returns the number of bytes of a packed uint32 given its first byte.
+func PackedLenUint32(bitmap byte) int {
+	return packuint.PackedLenUint32(bitmap)
+}
+
 // PackUint32To packs x to w.
 //
 // buf is used as scratch space if it has at least 5 bytes in capacity.
@@ -58,6 +89,7 @@ func UnpackUint32(buf []byte) uint32 {
 }
 
 // UnpackUint32From unpacks an uint32 from r.
+// Use a Decoder to read several values from the same reader.
 func UnpackUint32From(r io.Reader, buf []byte) (uint32, error) {
 	return packuint.UnpackUint32From(iobyte.NewReader(r), buf)
 }
@@ -0,0 +1,32 @@
+package packer
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAppendUint(t *testing.T) {
+	values := []uint64{0, 1, 1 << 10, 0xF0F0F0F0, math.MaxUint64}
+	var buf []byte
+	size := 0
+	for _, x := range values {
+		buf = AppendUint64(buf, x)
+		buf = AppendUint32(buf, uint32(x))
+		size += PackedSizeUint64(x) + PackedSizeUint32(uint32(x))
+	}
+	if got, want := len(buf), size; got != want {
+		t.Fatalf("got %d bytes; want %d", got, want)
+	}
+	for _, x := range values {
+		n := PackedLenUint64(buf[0])
+		if got := UnpackUint64(buf[:n]); got != x {
+			t.Fatalf("got %d; want %d", got, x)
+		}
+		buf = buf[n:]
+		n = PackedLenUint32(buf[0])
+		if got := UnpackUint32(buf[:n]); got != uint32(x) {
+			t.Fatalf("got %d; want %d", got, uint32(x))
+		}
+		buf = buf[n:]
+	}
+}